@@ -25,6 +25,7 @@ type AdminCreateParams struct {
 	Type       string     `json:"type,omitempty"`
 }
 
+// AdminUpdateParams only sends the fields set, empty fields are left unchanged.
 type AdminUpdateParams struct {
 	Email    string     `json:"email,omitempty"`
 	Metadata []Metadata `json:"metadata,omitempty"`
@@ -3,11 +3,13 @@ package client
 import (
 	"fmt"
 	"github.com/onfleet/gonfleet/service/routePlan"
+	"net/http"
 	"time"
 
 	"github.com/onfleet/gonfleet/netwrk"
 	"github.com/onfleet/gonfleet/service/admin"
 	"github.com/onfleet/gonfleet/service/container"
+	"github.com/onfleet/gonfleet/service/customField"
 	"github.com/onfleet/gonfleet/service/destination"
 	"github.com/onfleet/gonfleet/service/hub"
 	"github.com/onfleet/gonfleet/service/organization"
@@ -33,6 +35,7 @@ const (
 type API struct {
 	Administrators   *admin.Client
 	Containers       *container.Client
+	CustomFields     *customField.Client
 	Destinations     *destination.Client
 	Hubs             *hub.Client
 	Organizations    *organization.Client
@@ -53,6 +56,23 @@ type InitParams struct {
 	Path              string
 	ApiVersion        string
 	MaxCallsPerSecond int
+	// HttpClient replaces the http client used for every service call.
+	// It is used as is, so UserTimeout is not applied to it.
+	HttpClient *http.Client
+	// Transport sets the RoundTripper of the default http client, e.g. for
+	// proxies, custom TLS roots or instrumentation.
+	// Ignored when HttpClient is set.
+	Transport http.RoundTripper
+	// Middleware intercepts every request / response made by the service
+	// clients. Middleware[0] is the outermost.
+	Middleware []netwrk.Middleware
+	// RetryPolicy controls retries of failed calls.
+	// nil uses netwrk.DefaultRetryPolicy which only retries throttled calls.
+	RetryPolicy *netwrk.RetryPolicy
+	// ValidateParams validates task params before Tasks.Create, BatchCreate,
+	// BatchCreateAsync and Update, failing with an onfleet.ValidationError
+	// instead of an InvalidArgument round trip.
+	ValidateParams bool
 }
 
 func New(apiKey string, params *InitParams) (*API, error) {
@@ -88,6 +108,15 @@ func New(apiKey string, params *InitParams) (*API, error) {
 		rate.NewLimiter(rate.Every(1*time.Second), maxCallsPerSecond),
 		timeout,
 	)
+	if params != nil {
+		if params.HttpClient != nil {
+			rlHttpClient.Client = params.HttpClient
+		} else if params.Transport != nil {
+			rlHttpClient.Client.Transport = params.Transport
+		}
+		rlHttpClient.Middleware = params.Middleware
+		rlHttpClient.RetryPolicy = params.RetryPolicy
+	}
 
 	fullBaseUrl := baseUrl + path + apiVersion
 
@@ -103,6 +132,12 @@ func New(apiKey string, params *InitParams) (*API, error) {
 		fullBaseUrl+"/containers",
 		netwrk.Call,
 	)
+	api.CustomFields = customField.Plug(
+		apiKey,
+		rlHttpClient,
+		fullBaseUrl+"/customFields",
+		netwrk.Call,
+	)
 	api.Destinations = destination.Plug(
 		apiKey,
 		rlHttpClient,
@@ -134,6 +169,9 @@ func New(apiKey string, params *InitParams) (*API, error) {
 		fullBaseUrl+"/tasks",
 		netwrk.Call,
 	)
+	if params != nil {
+		api.Tasks.ValidateParams = params.ValidateParams
+	}
 	api.Teams = team.Plug(
 		apiKey,
 		rlHttpClient,
@@ -1,8 +1,12 @@
 package client
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/onfleet/gonfleet/netwrk"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -17,6 +21,7 @@ func TestNew_ValidAPIKey(t *testing.T) {
 	// Verify all services are initialized
 	assert.NotNil(t, api.Administrators)
 	assert.NotNil(t, api.Containers)
+	assert.NotNil(t, api.CustomFields)
 	assert.NotNil(t, api.Destinations)
 	assert.NotNil(t, api.Hubs)
 	assert.NotNil(t, api.Organizations)
@@ -71,6 +76,7 @@ func TestNew_CustomParameters(t *testing.T) {
 	// Verify all services are still initialized with custom parameters
 	assert.NotNil(t, api.Administrators)
 	assert.NotNil(t, api.Containers)
+	assert.NotNil(t, api.CustomFields)
 	assert.NotNil(t, api.Destinations)
 	assert.NotNil(t, api.Hubs)
 	assert.NotNil(t, api.Organizations)
@@ -268,6 +274,7 @@ func TestNew_URLConstruction(t *testing.T) {
 			assert.NotNil(t, api.Hubs)
 			assert.NotNil(t, api.Administrators)
 			assert.NotNil(t, api.Containers)
+			assert.NotNil(t, api.CustomFields)
 			assert.NotNil(t, api.RoutePlans)
 			assert.NotNil(t, api.ManifestProvider)
 		})
@@ -388,6 +395,7 @@ func TestAPI_ServiceEndpoints(t *testing.T) {
 	services := []interface{}{
 		api.Administrators,
 		api.Containers,
+		api.CustomFields,
 		api.Destinations,
 		api.Hubs,
 		api.Organizations,
@@ -407,7 +415,7 @@ func TestAPI_ServiceEndpoints(t *testing.T) {
 	}
 	
 	// Count to ensure we have all expected services
-	expectedServiceCount := 12
+	expectedServiceCount := 13
 	if len(services) != expectedServiceCount {
 		t.Errorf("Expected %d services, got %d", expectedServiceCount, len(services))
 	}
@@ -430,4 +438,98 @@ func TestNew_EmptyStringFields(t *testing.T) {
 	// Should still work with defaults
 	assert.NotNil(t, api.Tasks)
 	assert.NotNil(t, api.Workers)
-}
\ No newline at end of file
+}
+
+type countingRoundTripper struct {
+	count int
+	next  http.RoundTripper
+}
+
+func (rt *countingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt.count++
+	req.Header.Set("X-Test-Transport", "true")
+	return rt.next.RoundTrip(req)
+}
+
+func TestNew_CustomTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "true", r.Header.Get("X-Test-Transport"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"task_123"}`))
+	}))
+	defer server.Close()
+
+	transport := &countingRoundTripper{next: http.DefaultTransport}
+	api, err := New("test_api_key_123", &InitParams{
+		BaseUrl:   server.URL,
+		Transport: transport,
+	})
+	assert.NoError(t, err)
+
+	task, err := api.Tasks.Get("task_123")
+	assert.NoError(t, err)
+	assert.Equal(t, "task_123", task.ID)
+
+	_, err = api.Workers.List()
+	assert.Error(t, err) // body is not a worker list, request still goes through transport
+	assert.Equal(t, 2, transport.count)
+}
+
+func TestNew_CustomHttpClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	transport := &countingRoundTripper{next: http.DefaultTransport}
+	ignoredTransport := &countingRoundTripper{next: http.DefaultTransport}
+	httpClient := &http.Client{Transport: transport}
+	api, err := New("test_api_key_123", &InitParams{
+		BaseUrl:     server.URL,
+		UserTimeout: 1000,
+		HttpClient:  httpClient,
+		Transport:   ignoredTransport,
+	})
+	assert.NoError(t, err)
+
+	_, err = api.Teams.List()
+	assert.NoError(t, err)
+	_, err = api.Webhooks.List()
+	assert.NoError(t, err)
+
+	assert.Equal(t, 2, transport.count)
+	assert.Equal(t, 0, ignoredTransport.count)
+	assert.Equal(t, time.Duration(0), httpClient.Timeout)
+}
+
+func TestNew_Middleware(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "req-1", r.Header.Get("X-Request-Id"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	var seen []string
+	api, err := New("test_api_key_123", &InitParams{
+		BaseUrl: server.URL,
+		Middleware: []netwrk.Middleware{
+			func(next netwrk.Doer) netwrk.Doer {
+				return func(request *http.Request) (*http.Response, error) {
+					request.Header.Set("X-Request-Id", "req-1")
+					seen = append(seen, request.URL.Path)
+					return next(request)
+				}
+			},
+		},
+	})
+	assert.NoError(t, err)
+
+	_, err = api.Teams.List()
+	assert.NoError(t, err)
+	_, err = api.Workers.List()
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"/api/v2/teams", "/api/v2/workers"}, seen)
+}
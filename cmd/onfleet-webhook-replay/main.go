@@ -0,0 +1,64 @@
+// Command onfleet-webhook-replay helps develop webhook consumers without a
+// public url.
+//
+//	onfleet-webhook-replay record -listen :8080 -out deliveries.jsonl
+//	onfleet-webhook-replay replay -in deliveries.jsonl -target http://localhost:3000/onfleet -secret $SECRET
+//	onfleet-webhook-replay synth -trigger taskCompleted | onfleet-webhook-replay replay -target http://localhost:3000/onfleet -secret $SECRET
+//
+// record stores Onfleet deliveries, e.g. reaching it through a tunnel, as
+// JSONL. replay posts recorded or synthesized deliveries to a local handler,
+// signed with the given secret the way Onfleet signs them. synth builds
+// deliveries from the testingutil task and worker fixtures.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// delivery is one line of a JSONL recording.
+type delivery struct {
+	Received time.Time `json:"received"`
+	// Signature is the X-Onfleet-Signature received, empty for synthesized
+	// deliveries. replay always signs again with its secret.
+	Signature string          `json:"signature,omitempty"`
+	Body      json.RawMessage `json:"body"`
+}
+
+const usage = `usage: onfleet-webhook-replay <command> [flags]
+
+commands:
+  record   serve deliveries and append them to a JSONL file
+  replay   post a JSONL file of deliveries to a handler, signed with a secret
+  synth    write deliveries built from testingutil fixtures as JSONL
+
+run onfleet-webhook-replay <command> -h for the flags of a command
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+	var err error
+	switch os.Args[1] {
+	case "record":
+		err = record(os.Args[2:])
+	case "replay":
+		err = replay(os.Args[2:])
+	case "synth":
+		err = synth(os.Args[2:], os.Stdout)
+	case "-h", "-help", "--help", "help":
+		fmt.Fprint(os.Stdout, usage)
+		return
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s", os.Args[1], usage)
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "onfleet-webhook-replay:", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onfleet/gonfleet"
+	"github.com/onfleet/gonfleet/webhook/receiver"
+)
+
+const testSecret = "8a3f0e1b2c4d"
+
+func TestSynthReplay(t *testing.T) {
+	var recorded bytes.Buffer
+	require.NoError(t, synth([]string{"-trigger", "taskCompleted", "-n", "2"}, &recorded))
+
+	var received []onfleet.WebhookPayload
+	rc, err := receiver.New(testSecret, func(ctx context.Context, payload onfleet.WebhookPayload) error {
+		received = append(received, payload)
+		return nil
+	}, nil)
+	require.NoError(t, err)
+	server := httptest.NewServer(rc)
+	defer server.Close()
+
+	err = replayDeliveries(&recorded, replayOptions{
+		target: server.URL,
+		secret: testSecret,
+		retime: true,
+		client: server.Client(),
+	})
+	require.NoError(t, err)
+
+	require.Len(t, received, 2)
+	event, err := received[1].Event()
+	require.NoError(t, err)
+	completed, ok := event.(onfleet.TaskCompletedEvent)
+	require.True(t, ok, "got %T", event)
+	assert.Equal(t, "task_123_1", completed.TaskId)
+	assert.Equal(t, "task_123_1", completed.Data.Task.ID)
+	assert.Equal(t, onfleet.TaskStateCompleted, completed.Data.Task.State)
+	require.NotNil(t, completed.Data.Worker)
+	assert.Equal(t, "worker_123", completed.Data.Worker.ID)
+}
+
+func TestSynth_WorkerTrigger(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, synth([]string{"-trigger", "workerDuty"}, &out))
+	var d delivery
+	require.NoError(t, json.Unmarshal(out.Bytes(), &d))
+	assert.JSONEq(t, `"worker_123"`, string(mustField(t, d.Body, "workerId")))
+	assert.Contains(t, string(mustField(t, d.Body, "data")), `"worker":{`)
+
+	assert.Error(t, synth([]string{"-trigger", "taskExploded"}, &out))
+}
+
+func mustField(t *testing.T, body []byte, name string) json.RawMessage {
+	t.Helper()
+	var fields map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(body, &fields))
+	return fields[name]
+}
+
+func TestRecorder(t *testing.T) {
+	var out bytes.Buffer
+	server := httptest.NewServer(recorder(&out))
+	defer server.Close()
+
+	response, err := http.Get(server.URL + "?check=abc")
+	require.NoError(t, err)
+	response.Body.Close()
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+
+	request, _ := http.NewRequest(http.MethodPost, server.URL, strings.NewReader(`{"time": 1, "triggerId": 3}`))
+	request.Header.Set(receiver.SignatureHeader, "f00d")
+	response, err = http.DefaultClient.Do(request)
+	require.NoError(t, err)
+	response.Body.Close()
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+
+	response, err = http.Post(server.URL, "text/plain", strings.NewReader("not json"))
+	require.NoError(t, err)
+	response.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+
+	var d delivery
+	require.NoError(t, json.Unmarshal(out.Bytes(), &d))
+	assert.Equal(t, "f00d", d.Signature)
+	assert.JSONEq(t, `{"time": 1, "triggerId": 3}`, string(d.Body))
+	assert.WithinDuration(t, time.Now(), d.Received, time.Minute)
+}
+
+func TestRetime(t *testing.T) {
+	body, err := retime([]byte(`{"time": 1, "taskId": "t"}`), time.UnixMilli(1700000000000))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"time": 1700000000000, "taskId": "t"}`, string(body))
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/onfleet/gonfleet/webhook/receiver"
+)
+
+func record(args []string) error {
+	flags := flag.NewFlagSet("record", flag.ExitOnError)
+	listen := flags.String("listen", ":8080", "address to listen on")
+	out := flags.String("out", "deliveries.jsonl", "JSONL file deliveries are appended to")
+	flags.Parse(args)
+
+	file, err := os.OpenFile(*out, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	log.Printf("recording deliveries to %s, listening on %s", *out, *listen)
+	return http.ListenAndServe(*listen, recorder(file))
+}
+
+// recorder answers the ?check= validation request and appends every POSTed
+// delivery to w.
+func recorder(w io.Writer) http.Handler {
+	var mu sync.Mutex
+	encoder := json.NewEncoder(w)
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			io.WriteString(rw, r.URL.Query().Get("check"))
+		case http.MethodPost:
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				http.Error(rw, err.Error(), http.StatusBadRequest)
+				return
+			}
+			if !json.Valid(body) {
+				log.Printf("skipping delivery which is not json: %.100s", body)
+				http.Error(rw, "body is not json", http.StatusBadRequest)
+				return
+			}
+			mu.Lock()
+			err = encoder.Encode(delivery{
+				Received:  time.Now(),
+				Signature: r.Header.Get(receiver.SignatureHeader),
+				Body:      body,
+			})
+			mu.Unlock()
+			if err != nil {
+				log.Printf("recording delivery: %v", err)
+				http.Error(rw, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			log.Printf("recorded %d bytes", len(body))
+		default:
+			http.Error(rw, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		}
+	})
+}
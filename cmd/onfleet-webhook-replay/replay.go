@@ -0,0 +1,124 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/onfleet/gonfleet/webhook/receiver"
+)
+
+type replayOptions struct {
+	target string
+	secret string
+	// retime sets each payload's time to now so receivers checking its age
+	// accept old recordings.
+	retime bool
+	delay  time.Duration
+	client *http.Client
+}
+
+func replay(args []string) error {
+	flags := flag.NewFlagSet("replay", flag.ExitOnError)
+	in := flags.String("in", "-", "JSONL file of deliveries, - for stdin")
+	target := flags.String("target", "", "url of the handler to post deliveries to")
+	secret := flags.String("secret", os.Getenv("ONFLEET_WEBHOOK_SECRET"), "hex webhook secret to sign with, defaults to $ONFLEET_WEBHOOK_SECRET")
+	retime := flags.Bool("retime", true, "set each payload's time to now")
+	delay := flags.Duration("delay", 0, "wait between deliveries")
+	flags.Parse(args)
+	if *target == "" {
+		return errors.New("replay: -target is required")
+	}
+	if *secret == "" {
+		return errors.New("replay: -secret is required")
+	}
+
+	var r io.Reader = os.Stdin
+	if *in != "-" {
+		file, err := os.Open(*in)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		r = file
+	}
+	return replayDeliveries(r, replayOptions{
+		target: *target,
+		secret: *secret,
+		retime: *retime,
+		delay:  *delay,
+		client: http.DefaultClient,
+	})
+}
+
+// replayDeliveries posts every delivery read from r, stopping at the first
+// which cannot be sent. Deliveries rejected by the handler are logged.
+func replayDeliveries(r io.Reader, opts replayOptions) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64<<10), 16<<20)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var d delivery
+		if err := json.Unmarshal(scanner.Bytes(), &d); err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+		body := []byte(d.Body)
+		if opts.retime {
+			var err error
+			if body, err = retime(body, time.Now()); err != nil {
+				return fmt.Errorf("line %d: %w", line, err)
+			}
+		}
+		status, err := post(opts, body)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+		log.Printf("line %d: %d %s", line, status, http.StatusText(status))
+		if opts.delay > 0 {
+			time.Sleep(opts.delay)
+		}
+	}
+	return scanner.Err()
+}
+
+func post(opts replayOptions, body []byte) (int, error) {
+	signature, err := receiver.Sign(opts.secret, body)
+	if err != nil {
+		return 0, fmt.Errorf("signing: %w", err)
+	}
+	request, err := http.NewRequest(http.MethodPost, opts.target, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(receiver.SignatureHeader, signature)
+	response, err := opts.client.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+	io.Copy(io.Discard, response.Body)
+	return response.StatusCode, nil
+}
+
+// retime replaces the payload's time field, keeping the other fields as is.
+func retime(body []byte, now time.Time) ([]byte, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return nil, err
+	}
+	fields["time"] = json.RawMessage(fmt.Sprint(now.UnixMilli()))
+	return json.Marshal(fields)
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"time"
+
+	"github.com/onfleet/gonfleet"
+	"github.com/onfleet/gonfleet/testingutil"
+)
+
+func synth(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("synth", flag.ExitOnError)
+	triggerName := flags.String("trigger", "taskCompleted", "trigger name, e.g. taskStarted or workerDuty")
+	count := flags.Int("n", 1, "number of deliveries, each for a distinct task id")
+	out := flags.String("out", "-", "JSONL file to write, - for stdout")
+	flags.Parse(args)
+
+	trigger, err := onfleet.ParseWebhookTrigger(*triggerName)
+	if err != nil {
+		return err
+	}
+	w := stdout
+	if *out != "-" {
+		file, err := os.Create(*out)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		w = file
+	}
+	encoder := json.NewEncoder(w)
+	now := time.Now()
+	for i := 0; i < *count; i++ {
+		body, err := synthPayload(trigger, i, now)
+		if err != nil {
+			return err
+		}
+		if err := encoder.Encode(delivery{Received: now, Body: body}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// synthPayload builds a payload for trigger from the testingutil fixtures.
+// The i-th payload gets its own task id so that receivers do not treat
+// them as duplicates.
+func synthPayload(trigger onfleet.WebhookTrigger, i int, now time.Time) (json.RawMessage, error) {
+	worker := testingutil.GetSampleWorker()
+	task := testingutil.GetSampleTask()
+	task.ID = fmt.Sprintf("%s_%d", task.ID, i)
+	task.Worker = &worker.ID
+	switch trigger {
+	case onfleet.WebhookTriggerTaskStarted:
+		task.State = onfleet.TaskStateActive
+	case onfleet.WebhookTriggerTaskCompleted, onfleet.WebhookTriggerTaskFailed:
+		task.State = onfleet.TaskStateCompleted
+	}
+
+	meta := onfleet.WebhookEventMeta{
+		Time:        now.UnixMilli(),
+		TriggerId:   trigger,
+		TriggerName: trigger.String(),
+	}
+	// the typed event of the trigger tells which data it carries
+	event, err := onfleet.WebhookPayload{WebhookEventMeta: meta}.Event()
+	if err != nil {
+		return nil, err
+	}
+	var data any = map[string]any{}
+	switch eventType := reflect.TypeOf(event); {
+	case eventType.ConvertibleTo(reflect.TypeOf(onfleet.TaskEvent{})):
+		meta.TaskId = task.ID
+		meta.WorkerId = &worker.ID
+		data = onfleet.TaskEventData{Task: task, Worker: &worker}
+	case eventType.ConvertibleTo(reflect.TypeOf(onfleet.WorkerEvent{})):
+		meta.WorkerId = &worker.ID
+		data = onfleet.WorkerEventData{Worker: worker}
+	}
+
+	rawData, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(onfleet.WebhookPayload{WebhookEventMeta: meta, Data: rawData})
+}
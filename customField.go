@@ -39,3 +39,17 @@ const (
 	CustomFieldValidDataTypeDate           = "date"
 	CustomFieldValidDataTypeURL            = "Url"
 )
+
+// CustomFieldModelTask is the model of task custom fields, the only model
+// with custom fields.
+const CustomFieldModelTask = "Task"
+
+// CustomFieldUpdateParams changes the definition of the custom field Key.
+// Only the fields set are sent.
+type CustomFieldUpdateParams struct {
+	Key         string                        `json:"key"`
+	Description string                        `json:"description,omitempty"`
+	Editability []CustomFieldVisibilityOption `json:"editability,omitempty"`
+	Name        string                        `json:"name,omitempty"`
+	Visibility  []CustomFieldVisibilityOption `json:"visibility,omitempty"`
+}
@@ -0,0 +1,166 @@
+package onfleet
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// CustomFieldText sets the value of a single or multi line text field.
+func CustomFieldText(key string, value string) CustomFieldParams {
+	return CustomFieldParams{Key: key, Value: value}
+}
+
+// CustomFieldBoolean sets the value of a boolean field.
+func CustomFieldBoolean(key string, value bool) CustomFieldParams {
+	return CustomFieldParams{Key: key, Value: value}
+}
+
+// CustomFieldInteger sets the value of an integer field.
+func CustomFieldInteger(key string, value int64) CustomFieldParams {
+	return CustomFieldParams{Key: key, Value: value}
+}
+
+// CustomFieldDecimal sets the value of a decimal field.
+func CustomFieldDecimal(key string, value float64) CustomFieldParams {
+	return CustomFieldParams{Key: key, Value: value}
+}
+
+// CustomFieldDate sets the value of a date field, sent as Millis.
+func CustomFieldDate(key string, value time.Time) CustomFieldParams {
+	return CustomFieldParams{Key: key, Value: FromTime(value)}
+}
+
+// CustomFieldURL sets the value of a url field.
+func CustomFieldURL(key string, value string) CustomFieldParams {
+	return CustomFieldParams{Key: key, Value: value}
+}
+
+// Param checks value against the field's type and builds its
+// CustomFieldParams. Values of AsArray fields are slices of the type.
+func (f CustomField) Param(value any) (CustomFieldParams, error) {
+	if err := f.ValidateValue(value); err != nil {
+		return CustomFieldParams{}, err
+	}
+	return CustomFieldParams{Key: f.Key, Value: value}, nil
+}
+
+// ValidateValue checks value is of the field's type: a string for text and
+// url fields, a bool, a whole number for integers, a number for decimals and
+// Millis for dates. Values of AsArray fields are slices of the type.
+func (f CustomField) ValidateValue(value any) error {
+	if !f.AsArray {
+		return validateCustomFieldValue(f.Type, value)
+	}
+	values := reflect.ValueOf(value)
+	if values.Kind() != reflect.Slice && values.Kind() != reflect.Array {
+		return fmt.Errorf("must be a list of %s, got %T", f.Type, value)
+	}
+	for i := 0; i < values.Len(); i++ {
+		if err := validateCustomFieldValue(f.Type, values.Index(i).Interface()); err != nil {
+			return fmt.Errorf("[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func validateCustomFieldValue(t CustomFieldValidDataType, value any) error {
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return fmt.Errorf("must be a %s, got nil", t)
+		}
+		v = v.Elem()
+	}
+	wrongType := fmt.Errorf("must be a %s, got %T", t, value)
+	switch t {
+	case CustomFieldValidDataTypeSingleLineText, CustomFieldValidDataTypeMultiLineText:
+		if v.Kind() != reflect.String {
+			return wrongType
+		}
+		if t == CustomFieldValidDataTypeSingleLineText && strings.ContainsAny(v.String(), "\r\n") {
+			return fmt.Errorf("must be a single line")
+		}
+	case CustomFieldValidDataTypeBoolean:
+		if v.Kind() != reflect.Bool {
+			return wrongType
+		}
+	case CustomFieldValidDataTypeInteger:
+		n, ok := customFieldNumber(v)
+		if !ok {
+			return wrongType
+		}
+		if n != math.Trunc(n) {
+			return fmt.Errorf("must be a whole number, got %v", n)
+		}
+	case CustomFieldValidDataTypeDecimal:
+		n, ok := customFieldNumber(v)
+		if !ok || math.IsNaN(n) || math.IsInf(n, 0) {
+			return wrongType
+		}
+	case CustomFieldValidDataTypeDate:
+		if v.Type() == reflect.TypeOf(time.Time{}) {
+			return fmt.Errorf("must be Millis, use CustomFieldDate or FromTime")
+		}
+		n, ok := customFieldNumber(v)
+		if !ok || n != math.Trunc(n) {
+			return fmt.Errorf("must be a date in Millis, got %T", value)
+		}
+	case CustomFieldValidDataTypeURL:
+		if v.Kind() != reflect.String {
+			return wrongType
+		}
+		u, err := url.Parse(v.String())
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("must be an http or https url, got %q", v.String())
+		}
+	default:
+		return fmt.Errorf("unknown custom field type %q", t)
+	}
+	return nil
+}
+
+// customFieldNumber returns v as a float64 if it is a number.
+func customFieldNumber(v reflect.Value) (float64, bool) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	}
+	return 0, false
+}
+
+// ValidateCustomFields checks every value of params against the definition
+// of its key in fields, e.g. listed by client.CustomFields.List, reporting
+// unknown keys and values of the wrong type as a ValidationError.
+func ValidateCustomFields(fields []CustomField, params []CustomFieldParams) error {
+	v := newValidator()
+	validateCustomFields(v, fields, params)
+	return v.result("CustomFieldParams")
+}
+
+func validateCustomFields(v validator, fields []CustomField, params []CustomFieldParams) {
+	definitions := make(map[string]CustomField, len(fields))
+	for _, field := range fields {
+		definitions[field.Key] = field
+	}
+	seen := map[string]bool{}
+	for i, param := range params {
+		path := fmt.Sprintf("customFields[%d]", i)
+		field, ok := definitions[param.Key]
+		if !ok {
+			v.check(false, path+".key", "unknown custom field %q", param.Key)
+			continue
+		}
+		v.check(!seen[param.Key], path+".key", "custom field %q is set more than once", param.Key)
+		seen[param.Key] = true
+		err := field.ValidateValue(param.Value)
+		v.check(err == nil, path+".value", "%s: %v", param.Key, err)
+	}
+}
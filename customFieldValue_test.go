@@ -0,0 +1,105 @@
+package onfleet
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCustomField_ValidateValue(t *testing.T) {
+	tests := []struct {
+		name  string
+		field CustomField
+		value any
+		err   string
+	}{
+		{"text", CustomField{Type: CustomFieldValidDataTypeSingleLineText}, "gate 4", ""},
+		{"text number", CustomField{Type: CustomFieldValidDataTypeSingleLineText}, 4, "must be a single_line_text_field"},
+		{"single line", CustomField{Type: CustomFieldValidDataTypeSingleLineText}, "a\nb", "single line"},
+		{"multi line", CustomField{Type: CustomFieldValidDataTypeMultiLineText}, "a\nb", ""},
+		{"boolean", CustomField{Type: CustomFieldValidDataTypeBoolean}, true, ""},
+		{"boolean string", CustomField{Type: CustomFieldValidDataTypeBoolean}, "true", "must be a boolean"},
+		{"integer", CustomField{Type: CustomFieldValidDataTypeInteger}, 3, ""},
+		{"integer decoded", CustomField{Type: CustomFieldValidDataTypeInteger}, 3.0, ""},
+		{"integer fraction", CustomField{Type: CustomFieldValidDataTypeInteger}, 3.5, "whole number"},
+		{"decimal", CustomField{Type: CustomFieldValidDataTypeDecimal}, 3.5, ""},
+		{"decimal string", CustomField{Type: CustomFieldValidDataTypeDecimal}, "3.5", "must be a decimal"},
+		{"date", CustomField{Type: CustomFieldValidDataTypeDate}, Millis(1709296200250), ""},
+		{"date time", CustomField{Type: CustomFieldValidDataTypeDate}, time.Now(), "use CustomFieldDate"},
+		{"url", CustomField{Type: CustomFieldValidDataTypeURL}, "https://example.com/pod", ""},
+		{"url relative", CustomField{Type: CustomFieldValidDataTypeURL}, "/pod", "http or https url"},
+		{"array", CustomField{Type: CustomFieldValidDataTypeInteger, AsArray: true}, []int{1, 2}, ""},
+		{"array element", CustomField{Type: CustomFieldValidDataTypeInteger, AsArray: true}, []any{1, "2"}, "[1]: must be a integer"},
+		{"array scalar", CustomField{Type: CustomFieldValidDataTypeInteger, AsArray: true}, 1, "must be a list"},
+		{"nil", CustomField{Type: CustomFieldValidDataTypeBoolean}, nil, "got <nil>"},
+		{"unknown type", CustomField{Type: "color"}, "red", "unknown custom field type"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.field.ValidateValue(tt.value)
+			if tt.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func TestCustomField_Param(t *testing.T) {
+	field := CustomField{Key: "boxes", Type: CustomFieldValidDataTypeInteger}
+	param, err := field.Param(3)
+	require.NoError(t, err)
+	assert.Equal(t, CustomFieldParams{Key: "boxes", Value: 3}, param)
+
+	_, err = field.Param("3")
+	assert.Error(t, err)
+}
+
+func TestCustomFieldValueHelpers(t *testing.T) {
+	at := time.UnixMilli(1709296200250)
+	data, err := json.Marshal([]CustomFieldParams{
+		CustomFieldText("gate", "4"),
+		CustomFieldBoolean("fragile", true),
+		CustomFieldInteger("boxes", 3),
+		CustomFieldDecimal("weight", 1.25),
+		CustomFieldDate("deliverBy", at),
+		CustomFieldURL("pod", "https://example.com/pod"),
+	})
+	require.NoError(t, err)
+	assert.JSONEq(t, `[
+		{"key":"gate","value":"4"},
+		{"key":"fragile","value":true},
+		{"key":"boxes","value":3},
+		{"key":"weight","value":1.25},
+		{"key":"deliverBy","value":1709296200250},
+		{"key":"pod","value":"https://example.com/pod"}
+	]`, string(data))
+}
+
+func TestValidateCustomFields(t *testing.T) {
+	fields := []CustomField{
+		{Key: "boxes", Type: CustomFieldValidDataTypeInteger},
+		{Key: "deliverBy", Type: CustomFieldValidDataTypeDate},
+	}
+	assert.NoError(t, ValidateCustomFields(fields, []CustomFieldParams{
+		CustomFieldInteger("boxes", 2),
+		CustomFieldDate("deliverBy", time.Now()),
+	}))
+
+	err := ValidateCustomFields(fields, []CustomFieldParams{
+		{Key: "boxes", Value: 2.5},
+		{Key: "color", Value: "red"},
+		CustomFieldInteger("boxes", 2),
+	})
+	var validationErr ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, []FieldError{
+		{Field: "customFields[0].value", Message: "boxes: must be a whole number, got 2.5"},
+		{Field: "customFields[1].key", Message: `unknown custom field "color"`},
+		{Field: "customFields[2].key", Message: `custom field "boxes" is set more than once`},
+	}, validationErr.Errors)
+}
@@ -2,8 +2,26 @@ package onfleet
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Onfleet error codes found in RequestError.Code.
+// Reference https://docs.onfleet.com/reference/errors
+const (
+	ErrorCodeInvalidContent     = "InvalidContent"
+	ErrorCodeInvalidArgument    = "InvalidArgument"
+	ErrorCodeUnauthorized       = "Unauthorized"
+	ErrorCodeForbidden          = "Forbidden"
+	ErrorCodeResourceNotFound   = "ResourceNotFound"
+	ErrorCodePreconditionFailed = "PreconditionFailed"
+	ErrorCodeTooManyRequests    = "TooManyRequests"
+	ErrorCodeInternalError      = "InternalError"
+	ErrorCodeServiceUnavailable = "ServiceUnavailable"
 )
 
 type RequestErrorMessage struct {
@@ -26,23 +44,185 @@ type RequestError struct {
 	Code string `json:"code,omitempty"`
 	// Message contains futher details about the error.
 	Message RequestErrorMessage `json:"message"`
+	// StatusCode is the http status of the response. 0 if unknown.
+	StatusCode int `json:"-"`
+	// RequestId identifies the request for Onfleet support.
+	RequestId string `json:"-"`
+	// header holds the response headers, behind a pointer so that
+	// RequestError stays comparable.
+	header *http.Header
+	// ContentType is the response Content-Type.
+	ContentType string `json:"-"`
+	// Body is the raw response body, truncated to maxErrorBodyLength bytes.
+	// Useful when the response is not an Onfleet error, e.g. an html 502
+	// from a load balancer.
+	Body string `json:"-"`
 }
 
+const (
+	// maxErrorBodyRead bounds how much of an error response is read.
+	maxErrorBodyRead = 64 << 10
+	// maxErrorBodyLength bounds RequestError.Body.
+	maxErrorBodyLength = 512
+)
+
 func (err RequestError) Error() string {
-	return fmt.Sprintf("%s: \n  Cause: %s\n  Message: %s", err.Code, err.Message.Cause, err.Message.Message)
+	code := err.Code
+	if code == "" {
+		code = http.StatusText(err.StatusCode)
+	}
+	if code == "" {
+		code = "RequestError"
+	}
+	if err.StatusCode != 0 {
+		code = fmt.Sprintf("%s (%d)", code, err.StatusCode)
+	}
+	if !err.isOnfleetError() {
+		return fmt.Sprintf("%s: \n  ContentType: %s\n  Body: %s", code, err.ContentType, err.Body)
+	}
+	return fmt.Sprintf("%s: \n  Cause: %v\n  Message: %s", code, err.Message.Cause, err.Message.Message)
+}
+
+// Header returns the response headers, nil if unknown.
+func (err RequestError) Header() http.Header {
+	if err.header == nil {
+		return nil
+	}
+	return *err.header
+}
+
+// isOnfleetError reports whether the error was decoded from Onfleet's
+// error envelope.
+func (err RequestError) isOnfleetError() bool {
+	return err.Code != "" || err.Message.Message != "" || err.Message.Error != 0
 }
 
+// ParseError decodes an Onfleet error response body.
+// A body which is empty, not json or not Onfleet's error envelope still
+// yields a RequestError, carrying the truncated raw body.
 func ParseError(r io.Reader) error {
+	body, err := io.ReadAll(io.LimitReader(r, maxErrorBodyRead))
+	reqError := parseErrorBody(body)
+	if err != nil && reqError.Body == "" {
+		reqError.Body = err.Error()
+	}
+	return reqError
+}
+
+func parseErrorBody(body []byte) RequestError {
+	var reqError RequestError
+	if err := json.Unmarshal(body, &reqError); err != nil || !reqError.isOnfleetError() {
+		reqError = RequestError{}
+	}
+	reqError.RequestId = reqError.Message.Request
+	if len(body) > maxErrorBodyLength {
+		body = body[:maxErrorBodyLength]
+	}
+	reqError.Body = strings.ToValidUTF8(string(body), "")
+	return reqError
+}
+
+// ParseResponseError builds a RequestError from an unsuccessful response,
+// keeping its status code, request id, content type and headers.
+func ParseResponseError(response *http.Response) error {
 	var reqError RequestError
-	if err := json.NewDecoder(r).Decode(&reqError); err != nil {
-		return err
+	errors.As(ParseError(response.Body), &reqError)
+	reqError.StatusCode = response.StatusCode
+	reqError.header = &response.Header
+	reqError.ContentType = response.Header.Get("Content-Type")
+	if reqError.RequestId == "" {
+		reqError.RequestId = response.Header.Get("X-Request-Id")
 	}
 	return reqError
 }
 
+// TooManyRequestsError is returned when Onfleet throttles a request
+// with a 429 response, or a 412 whose error code is TooManyRequests.
+// Any other 412 is a RequestError matched by IsPreconditionFailed.
 type TooManyRequestsError struct {
+	// StatusCode is the http status of the throttled response.
+	StatusCode int
+	// RetryAfter is the wait requested by the Retry-After header, 0 if absent.
+	RetryAfter time.Duration
+	// RateLimitLimit is the X-RateLimit-Limit header, 0 if absent.
+	RateLimitLimit int
+	// RateLimitRemaining is the X-RateLimit-Remaining header, 0 if absent.
+	RateLimitRemaining int
+	// RateLimitReset is when the rate limit window resets per the
+	// X-RateLimit-Reset header, zero if absent.
+	RateLimitReset time.Time
 }
 
 func (err TooManyRequestsError) Error() string {
+	if err.RetryAfter > 0 {
+		return fmt.Sprintf("too many requests, retry after %s", err.RetryAfter)
+	}
 	return "too many requests"
 }
+
+// Is matches any TooManyRequestsError so that
+// errors.Is(err, onfleet.TooManyRequestsError{}) holds regardless of the
+// header details.
+func (err TooManyRequestsError) Is(target error) bool {
+	_, ok := target.(TooManyRequestsError)
+	return ok
+}
+
+// requestErrorIs reports whether err wraps a RequestError with one of the
+// given codes or the given status.
+func requestErrorIs(err error, status int, codes ...string) bool {
+	var reqError RequestError
+	if !errors.As(err, &reqError) {
+		return false
+	}
+	if status != 0 && reqError.StatusCode == status {
+		return true
+	}
+	for _, code := range codes {
+		if reqError.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+// IsNotFound reports whether err is a 404 / ResourceNotFound error.
+func IsNotFound(err error) bool {
+	return requestErrorIs(err, http.StatusNotFound, ErrorCodeResourceNotFound)
+}
+
+// IsInvalidArgument reports whether err is an InvalidArgument / InvalidContent error.
+func IsInvalidArgument(err error) bool {
+	return requestErrorIs(err, 0, ErrorCodeInvalidArgument, ErrorCodeInvalidContent)
+}
+
+// IsUnauthorized reports whether err is a 401 / Unauthorized error.
+func IsUnauthorized(err error) bool {
+	return requestErrorIs(err, http.StatusUnauthorized, ErrorCodeUnauthorized)
+}
+
+// IsForbidden reports whether err is a 403 / Forbidden error.
+func IsForbidden(err error) bool {
+	return requestErrorIs(err, http.StatusForbidden, ErrorCodeForbidden)
+}
+
+// IsPreconditionFailed reports whether err is a PreconditionFailed error.
+func IsPreconditionFailed(err error) bool {
+	return requestErrorIs(err, 0, ErrorCodePreconditionFailed)
+}
+
+// IsTooManyRequests reports whether err is a throttling error.
+func IsTooManyRequests(err error) bool {
+	return errors.Is(err, TooManyRequestsError{}) || requestErrorIs(err, 0, ErrorCodeTooManyRequests)
+}
+
+// IsServerError reports whether err is a 5xx error.
+func IsServerError(err error) bool {
+	var reqError RequestError
+	if !errors.As(err, &reqError) {
+		return false
+	}
+	return reqError.StatusCode >= 500 ||
+		reqError.Code == ErrorCodeInternalError ||
+		reqError.Code == ErrorCodeServiceUnavailable
+}
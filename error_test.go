@@ -0,0 +1,170 @@
+package onfleet
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newResponse(statusCode int, body string, header http.Header) *http.Response {
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		StatusCode: statusCode,
+		Header:     header,
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func TestParseResponseError(t *testing.T) {
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+	body := `{"code":"ResourceNotFound","message":{"error":1402,"message":"The requested resource does not exist.","cause":{"type":"task","id":"abc"},"request":"req-123"}}`
+
+	err := ParseResponseError(newResponse(http.StatusNotFound, body, header))
+
+	var reqError RequestError
+	assert.ErrorAs(t, err, &reqError)
+	assert.Equal(t, ErrorCodeResourceNotFound, reqError.Code)
+	assert.Equal(t, http.StatusNotFound, reqError.StatusCode)
+	assert.Equal(t, "req-123", reqError.RequestId)
+	assert.Equal(t, "application/json", reqError.Header().Get("Content-Type"))
+	assert.Equal(t, 1402, reqError.Message.Error)
+}
+
+func TestRequestError_Comparable(t *testing.T) {
+	header := http.Header{}
+	header.Set("X-Request-Id", "req-1")
+	err := ParseResponseError(newResponse(http.StatusBadRequest, `{"code":"InvalidArgument","message":{"message":"bad"}}`, header))
+
+	assert.NotPanics(t, func() {
+		assert.False(t, err == error(RequestError{Code: ErrorCodeInvalidArgument}))
+		assert.True(t, err == err)
+	})
+	assert.Nil(t, RequestError{}.Header())
+}
+
+func TestParseResponseError_RequestIdHeader(t *testing.T) {
+	header := http.Header{}
+	header.Set("X-Request-Id", "header-req")
+
+	err := ParseResponseError(newResponse(http.StatusBadRequest, `{"code":"InvalidArgument","message":{"message":"bad"}}`, header))
+
+	var reqError RequestError
+	assert.ErrorAs(t, err, &reqError)
+	assert.Equal(t, "header-req", reqError.RequestId)
+}
+
+func TestRequestError_Error(t *testing.T) {
+	err := RequestError{
+		Code:       ErrorCodeInvalidArgument,
+		StatusCode: http.StatusBadRequest,
+		Message: RequestErrorMessage{
+			Cause:   map[string]any{"field": "phone"},
+			Message: "Invalid phone",
+		},
+	}
+
+	msg := err.Error()
+	assert.Contains(t, msg, "InvalidArgument (400)")
+	assert.Contains(t, msg, "map[field:phone]")
+	assert.NotContains(t, msg, "%!s")
+	assert.Contains(t, msg, "Invalid phone")
+}
+
+func TestErrorPredicates(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		predicate func(error) bool
+		expected  bool
+	}{
+		{name: "not found by code", err: RequestError{Code: ErrorCodeResourceNotFound}, predicate: IsNotFound, expected: true},
+		{name: "not found by status", err: RequestError{StatusCode: http.StatusNotFound}, predicate: IsNotFound, expected: true},
+		{name: "not found wrapped", err: fmt.Errorf("get task: %w", RequestError{StatusCode: http.StatusNotFound}), predicate: IsNotFound, expected: true},
+		{name: "not found other code", err: RequestError{Code: ErrorCodeInvalidArgument, StatusCode: http.StatusBadRequest}, predicate: IsNotFound, expected: false},
+		{name: "not found plain error", err: fmt.Errorf("boom"), predicate: IsNotFound, expected: false},
+		{name: "not found nil", err: nil, predicate: IsNotFound, expected: false},
+		{name: "invalid argument", err: RequestError{Code: ErrorCodeInvalidArgument}, predicate: IsInvalidArgument, expected: true},
+		{name: "invalid content", err: RequestError{Code: ErrorCodeInvalidContent}, predicate: IsInvalidArgument, expected: true},
+		{name: "unauthorized", err: RequestError{StatusCode: http.StatusUnauthorized}, predicate: IsUnauthorized, expected: true},
+		{name: "forbidden", err: RequestError{Code: ErrorCodeForbidden}, predicate: IsForbidden, expected: true},
+		{name: "precondition failed", err: RequestError{Code: ErrorCodePreconditionFailed}, predicate: IsPreconditionFailed, expected: true},
+		{name: "too many requests", err: fmt.Errorf("wrapped: %w", TooManyRequestsError{StatusCode: 429}), predicate: IsTooManyRequests, expected: true},
+		{name: "server error", err: RequestError{StatusCode: http.StatusBadGateway}, predicate: IsServerError, expected: true},
+		{name: "not server error", err: RequestError{StatusCode: http.StatusBadRequest}, predicate: IsServerError, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.predicate(tt.err))
+		})
+	}
+}
+
+func TestParseResponseError_NonJSONBody(t *testing.T) {
+	header := http.Header{}
+	header.Set("Content-Type", "text/html")
+	html := "<html><body><h1>502 Bad Gateway</h1>" + strings.Repeat("x", 1000) + "</body></html>"
+
+	err := ParseResponseError(newResponse(http.StatusBadGateway, html, header))
+
+	var reqError RequestError
+	assert.ErrorAs(t, err, &reqError)
+	assert.Equal(t, http.StatusBadGateway, reqError.StatusCode)
+	assert.Equal(t, "text/html", reqError.ContentType)
+	assert.Equal(t, "", reqError.Code)
+	assert.Len(t, reqError.Body, maxErrorBodyLength)
+	assert.True(t, strings.HasPrefix(reqError.Body, "<html>"))
+	assert.True(t, IsServerError(err))
+	assert.Contains(t, err.Error(), "Bad Gateway (502)")
+	assert.Contains(t, err.Error(), "text/html")
+}
+
+func TestParseResponseError_EmptyBody(t *testing.T) {
+	err := ParseResponseError(newResponse(http.StatusServiceUnavailable, "", nil))
+
+	var reqError RequestError
+	assert.ErrorAs(t, err, &reqError)
+	assert.Equal(t, http.StatusServiceUnavailable, reqError.StatusCode)
+	assert.Equal(t, "", reqError.Body)
+	assert.Contains(t, err.Error(), "Service Unavailable (503)")
+}
+
+func TestParseError_NotOnfleetEnvelope(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{name: "json array", body: `["unexpected"]`},
+		{name: "json object without envelope", body: `{"status":"down"}`},
+		{name: "plain text", body: "upstream connect error"},
+		{name: "truncated json", body: `{"code":"InvalidArg`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ParseError(strings.NewReader(tt.body))
+
+			var reqError RequestError
+			assert.ErrorAs(t, err, &reqError)
+			assert.Equal(t, "", reqError.Code)
+			assert.Equal(t, tt.body, reqError.Body)
+		})
+	}
+}
+
+func TestParseError_TruncatesInvalidUTF8(t *testing.T) {
+	body := strings.Repeat("a", maxErrorBodyLength-1) + "é"
+
+	err := ParseError(strings.NewReader(body))
+
+	var reqError RequestError
+	assert.ErrorAs(t, err, &reqError)
+	assert.Equal(t, strings.Repeat("a", maxErrorBodyLength-1), reqError.Body)
+}
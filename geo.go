@@ -0,0 +1,186 @@
+package onfleet
+
+import (
+	"fmt"
+	"math"
+)
+
+// earthRadius is the mean earth radius in meters.
+const earthRadius = 6371008.8
+
+// LatLng builds a DestinationLocation, which Onfleet orders [lng, lat],
+// from a latitude and longitude.
+func LatLng(lat float64, lng float64) DestinationLocation {
+	return DestinationLocation{lng, lat}
+}
+
+// Lng returns the longitude, 0 if the location is not set.
+func (l DestinationLocation) Lng() float64 {
+	if len(l) < 2 {
+		return 0
+	}
+	return l[0]
+}
+
+// Lat returns the latitude, 0 if the location is not set.
+func (l DestinationLocation) Lat() float64 {
+	if len(l) < 2 {
+		return 0
+	}
+	return l[1]
+}
+
+// Validate checks the location is a [lng, lat] pair within range.
+// A latitude beyond ±90 usually means the coordinates are swapped.
+func (l DestinationLocation) Validate() error {
+	if len(l) != 2 {
+		return fmt.Errorf("location must be a [longitude, latitude] pair, got %d values", len(l))
+	}
+	if math.IsNaN(l[0]) || math.IsNaN(l[1]) || math.IsInf(l[0], 0) || math.IsInf(l[1], 0) {
+		return fmt.Errorf("location %v is not finite", []float64(l))
+	}
+	if l.Lat() < -90 || l.Lat() > 90 {
+		return fmt.Errorf("latitude %v must be between -90 and 90, is the location in [longitude, latitude] order?", l.Lat())
+	}
+	if l.Lng() < -180 || l.Lng() > 180 {
+		return fmt.Errorf("longitude %v must be between -180 and 180", l.Lng())
+	}
+	return nil
+}
+
+// DistanceTo returns the great circle distance to other in meters.
+func (l DestinationLocation) DistanceTo(other DestinationLocation) float64 {
+	lat1, lat2 := radians(l.Lat()), radians(other.Lat())
+	dLat := lat2 - lat1
+	dLng := radians(other.Lng() - l.Lng())
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadius * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// BearingTo returns the initial bearing to other in degrees clockwise from
+// north, in [0, 360).
+func (l DestinationLocation) BearingTo(other DestinationLocation) float64 {
+	lat1, lat2 := radians(l.Lat()), radians(other.Lat())
+	dLng := radians(other.Lng() - l.Lng())
+	y := math.Sin(dLng) * math.Cos(lat2)
+	x := math.Cos(lat1)*math.Sin(lat2) - math.Sin(lat1)*math.Cos(lat2)*math.Cos(dLng)
+	bearing := math.Mod(degrees(math.Atan2(y, x))+360, 360)
+	if bearing == 360 {
+		return 0
+	}
+	return bearing
+}
+
+func radians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
+func degrees(rad float64) float64 {
+	return rad * 180 / math.Pi
+}
+
+// Area is a region locations can be tested against, see BoundingBox,
+// Polygon and Circle.
+type Area interface {
+	// Contains reports whether l is within the area. Invalid locations are
+	// never contained.
+	Contains(l DestinationLocation) bool
+}
+
+// BoundingBox is the area between two corners. A box whose SouthWest
+// longitude is east of its NorthEast longitude crosses the antimeridian.
+type BoundingBox struct {
+	SouthWest DestinationLocation
+	NorthEast DestinationLocation
+}
+
+func (b BoundingBox) Contains(l DestinationLocation) bool {
+	if l.Validate() != nil {
+		return false
+	}
+	if l.Lat() < b.SouthWest.Lat() || l.Lat() > b.NorthEast.Lat() {
+		return false
+	}
+	west, east := b.SouthWest.Lng(), b.NorthEast.Lng()
+	if west <= east {
+		return l.Lng() >= west && l.Lng() <= east
+	}
+	return l.Lng() >= west || l.Lng() <= east
+}
+
+// Polygon is the area within a ring of vertices, which may be open or
+// closed. Edges are straight in [lng, lat] space and must not cross the
+// antimeridian.
+type Polygon []DestinationLocation
+
+func (p Polygon) Contains(l DestinationLocation) bool {
+	if l.Validate() != nil || len(p) < 3 {
+		return false
+	}
+	inside := false
+	x, y := l.Lng(), l.Lat()
+	for i, j := 0, len(p)-1; i < len(p); j, i = i, i+1 {
+		xi, yi := p[i].Lng(), p[i].Lat()
+		xj, yj := p[j].Lng(), p[j].Lat()
+		if (yi > y) != (yj > y) && x < (xj-xi)*(y-yi)/(yj-yi)+xi {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// Circle is the area within Radius meters of Center.
+type Circle struct {
+	Center DestinationLocation
+	Radius float64
+}
+
+func (c Circle) Contains(l DestinationLocation) bool {
+	if l.Validate() != nil {
+		return false
+	}
+	return c.Center.DistanceTo(l) <= c.Radius
+}
+
+// NewWorkersByLocationListQueryParams searches within radius meters of
+// center, 0 for the API default of 1000.
+func NewWorkersByLocationListQueryParams(center DestinationLocation, radius float64) WorkersByLocationListQueryParams {
+	return WorkersByLocationListQueryParams{
+		Longitude: center.Lng(),
+		Latitude:  center.Lat(),
+		Radius:    radius,
+	}
+}
+
+// FilterWorkers returns the workers located within area.
+func FilterWorkers(workers []Worker, area Area) []Worker {
+	var within []Worker
+	for _, worker := range workers {
+		if area.Contains(worker.Location) {
+			within = append(within, worker)
+		}
+	}
+	return within
+}
+
+// FilterTasks returns the tasks whose destination is within area.
+func FilterTasks(tasks []Task, area Area) []Task {
+	var within []Task
+	for _, task := range tasks {
+		if area.Contains(task.Destination.Location) {
+			within = append(within, task)
+		}
+	}
+	return within
+}
+
+// Within returns the workers located within area, e.g. a Polygon to narrow
+// down the radius search of Workers.ListWorkersByLocation.
+func (w WorkersByLocation) Within(area Area) []Worker {
+	return FilterWorkers(w.Workers, area)
+}
+
+// Within returns the tasks of the page whose destination is within area.
+func (t TasksPaginated) Within(area Area) []Task {
+	return FilterTasks(t.Tasks, area)
+}
@@ -0,0 +1,97 @@
+package onfleet
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	sanFrancisco = LatLng(37.7749, -122.4194)
+	losAngeles   = LatLng(34.0522, -118.2437)
+	oakland      = LatLng(37.8044, -122.2712)
+)
+
+func TestDestinationLocation_Accessors(t *testing.T) {
+	assert.Equal(t, DestinationLocation{-122.4194, 37.7749}, sanFrancisco)
+	assert.Equal(t, 37.7749, sanFrancisco.Lat())
+	assert.Equal(t, -122.4194, sanFrancisco.Lng())
+	assert.Zero(t, DestinationLocation{}.Lat())
+	assert.Zero(t, DestinationLocation(nil).Lng())
+}
+
+func TestDestinationLocation_Validate(t *testing.T) {
+	assert.NoError(t, sanFrancisco.Validate())
+	assert.ErrorContains(t, DestinationLocation{37.7749, -122.4194}.Validate(), "-90 and 90")
+	assert.ErrorContains(t, DestinationLocation{-190, 10}.Validate(), "longitude")
+	assert.ErrorContains(t, DestinationLocation{1}.Validate(), "pair")
+	assert.ErrorContains(t, DestinationLocation{math.NaN(), 0}.Validate(), "finite")
+
+	err := DestinationCreateParams{
+		Address:  DestinationAddress{Unparsed: "1 Main St"},
+		Location: DestinationLocation{37.7749, -122.4194},
+	}.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "location: latitude")
+}
+
+func TestDestinationLocation_DistanceAndBearing(t *testing.T) {
+	assert.InDelta(t, 559_000, sanFrancisco.DistanceTo(losAngeles), 2_000)
+	assert.InDelta(t, sanFrancisco.DistanceTo(losAngeles), losAngeles.DistanceTo(sanFrancisco), 1e-6)
+	assert.Zero(t, sanFrancisco.DistanceTo(sanFrancisco))
+
+	assert.InDelta(t, 136.5, sanFrancisco.BearingTo(losAngeles), 0.5)
+	assert.InDelta(t, 0, LatLng(0, 0).BearingTo(LatLng(1, 0)), 1e-9)
+	assert.InDelta(t, 90, LatLng(0, 0).BearingTo(LatLng(0, 1)), 1e-9)
+	assert.InDelta(t, 270, LatLng(0, 0).BearingTo(LatLng(0, -1)), 1e-9)
+}
+
+func TestAreas(t *testing.T) {
+	bayArea := BoundingBox{SouthWest: LatLng(37.2, -122.6), NorthEast: LatLng(38.1, -121.8)}
+	assert.True(t, bayArea.Contains(sanFrancisco))
+	assert.True(t, bayArea.Contains(oakland))
+	assert.False(t, bayArea.Contains(losAngeles))
+	assert.False(t, bayArea.Contains(DestinationLocation{}))
+
+	fiji := BoundingBox{SouthWest: LatLng(-21, 176), NorthEast: LatLng(-12, -178)}
+	assert.True(t, fiji.Contains(LatLng(-17, 179)))
+	assert.True(t, fiji.Contains(LatLng(-17, -179)))
+	assert.False(t, fiji.Contains(LatLng(-17, 170)))
+
+	// San Francisco peninsula, open ring
+	peninsula := Polygon{LatLng(37.81, -122.52), LatLng(37.81, -122.35), LatLng(37.70, -122.35), LatLng(37.70, -122.52)}
+	assert.True(t, peninsula.Contains(sanFrancisco))
+	assert.False(t, peninsula.Contains(oakland))
+	assert.False(t, Polygon{sanFrancisco, oakland}.Contains(sanFrancisco))
+
+	circle := Circle{Center: sanFrancisco, Radius: 20_000}
+	assert.True(t, circle.Contains(oakland))
+	assert.False(t, circle.Contains(losAngeles))
+}
+
+func TestFilterByArea(t *testing.T) {
+	area := Circle{Center: sanFrancisco, Radius: 20_000}
+
+	workers := WorkersByLocation{Workers: []Worker{
+		{ID: "sf", Location: sanFrancisco},
+		{ID: "la", Location: losAngeles},
+		{ID: "unknown"},
+	}}
+	within := workers.Within(area)
+	require.Len(t, within, 1)
+	assert.Equal(t, "sf", within[0].ID)
+
+	tasks := TasksPaginated{Tasks: []Task{
+		{ID: "oak", Destination: Destination{Location: oakland}},
+		{ID: "la", Destination: Destination{Location: losAngeles}},
+	}}
+	assert.Equal(t, []Task{tasks.Tasks[0]}, tasks.Within(area))
+	assert.Empty(t, FilterTasks(nil, area))
+}
+
+func TestNewWorkersByLocationListQueryParams(t *testing.T) {
+	params := NewWorkersByLocationListQueryParams(sanFrancisco, 5000)
+	assert.Equal(t, WorkersByLocationListQueryParams{Longitude: -122.4194, Latitude: 37.7749, Radius: 5000}, params)
+}
@@ -14,8 +14,10 @@ type HubCreateParams struct {
 	Teams   []string           `json:"teams,omitempty"`
 }
 
+// HubUpdateParams only sends the fields set, nil fields are left unchanged.
+// An empty, non nil, Teams removes the hub from every team.
 type HubUpdateParams struct {
-	Address DestinationAddress `json:"address"`
-	Name    string             `json:"name"`
-	Teams   []string           `json:"teams,omitempty"`
+	Address *DestinationAddress `json:"address,omitempty"`
+	Name    string              `json:"name,omitempty"`
+	Teams   *[]string           `json:"teams,omitempty"`
 }
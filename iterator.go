@@ -0,0 +1,119 @@
+package onfleet
+
+import "context"
+
+// IteratorOptions limits an Iterator.
+type IteratorOptions struct {
+	// PageSize is the number of items requested per page, 0 uses the API
+	// default. Only RoutePlans.All honours it; Tasks.All, Teams.AllTasks and
+	// Workers.AllTasks ignore it as their endpoints have a fixed page size.
+	PageSize int
+	// MaxItems stops the iterator after this many items. 0 means no limit.
+	MaxItems int
+}
+
+// PageFetcher fetches the page following lastId.
+// lastId is empty for the first page. An empty nextLastId ends iteration.
+type PageFetcher[T any] func(ctx context.Context, lastId string) (items []T, nextLastId string, err error)
+
+// Iterator follows lastId pagination until it is exhausted.
+//
+//	it := client.Tasks.All(ctx, params, nil)
+//	for it.Next() {
+//		task := it.Value()
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type Iterator[T any] struct {
+	ctx     context.Context
+	fetch   PageFetcher[T]
+	opts    IteratorOptions
+	lastId  string
+	page    []T
+	index   int
+	count   int
+	started bool
+	value   T
+	err     error
+}
+
+// NewIterator returns an Iterator starting after lastId.
+// opts may be nil.
+func NewIterator[T any](ctx context.Context, lastId string, fetch PageFetcher[T], opts *IteratorOptions) *Iterator[T] {
+	it := &Iterator[T]{
+		ctx:    ctx,
+		fetch:  fetch,
+		lastId: lastId,
+	}
+	if opts != nil {
+		it.opts = *opts
+	}
+	return it
+}
+
+// Next advances to the next item, fetching pages as needed.
+// It returns false once pagination is exhausted, MaxItems is reached or
+// an error occurs.
+func (it *Iterator[T]) Next() bool {
+	for {
+		if it.err != nil {
+			return false
+		}
+		if it.opts.MaxItems > 0 && it.count >= it.opts.MaxItems {
+			return false
+		}
+		if it.index < len(it.page) {
+			it.value = it.page[it.index]
+			it.index++
+			it.count++
+			return true
+		}
+		if it.started && it.lastId == "" {
+			return false
+		}
+		if err := it.ctx.Err(); err != nil {
+			it.err = err
+			return false
+		}
+		items, nextLastId, err := it.fetch(it.ctx, it.lastId)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		if it.started && nextLastId == it.lastId {
+			// guard against a cursor which does not advance
+			nextLastId = ""
+		}
+		it.started = true
+		it.page = items
+		it.index = 0
+		it.lastId = nextLastId
+	}
+}
+
+// Value returns the current item.
+func (it *Iterator[T]) Value() T {
+	return it.value
+}
+
+// Err returns the error which stopped iteration, if any.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// LastId returns the cursor of the next page to fetch, empty once exhausted.
+// It can be used to resume iteration later, once the current page has been
+// consumed.
+func (it *Iterator[T]) LastId() string {
+	return it.lastId
+}
+
+// Collect drains the iterator into a slice.
+func (it *Iterator[T]) Collect() ([]T, error) {
+	var items []T
+	for it.Next() {
+		items = append(items, it.Value())
+	}
+	return items, it.Err()
+}
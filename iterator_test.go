@@ -0,0 +1,113 @@
+package onfleet
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type page struct {
+	items  []int
+	lastId string
+}
+
+func pagedFetcher(pages map[string]page, calls *[]string) PageFetcher[int] {
+	return func(ctx context.Context, lastId string) ([]int, string, error) {
+		*calls = append(*calls, lastId)
+		p, ok := pages[lastId]
+		if !ok {
+			return nil, "", errors.New("unknown page " + lastId)
+		}
+		return p.items, p.lastId, nil
+	}
+}
+
+func TestIterator_FollowsLastId(t *testing.T) {
+	var calls []string
+	pages := map[string]page{
+		"":  {items: []int{1, 2}, lastId: "a"},
+		"a": {items: []int{3}, lastId: "b"},
+		"b": {items: []int{}, lastId: "c"},
+		"c": {items: []int{4, 5}},
+	}
+
+	items, err := NewIterator(context.Background(), "", pagedFetcher(pages, &calls), nil).Collect()
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, items)
+	assert.Equal(t, []string{"", "a", "b", "c"}, calls)
+}
+
+func TestIterator_StartsFromLastId(t *testing.T) {
+	var calls []string
+	pages := map[string]page{
+		"a": {items: []int{3}},
+	}
+
+	items, err := NewIterator(context.Background(), "a", pagedFetcher(pages, &calls), nil).Collect()
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int{3}, items)
+	assert.Equal(t, []string{"a"}, calls)
+}
+
+func TestIterator_MaxItems(t *testing.T) {
+	var calls []string
+	pages := map[string]page{
+		"":  {items: []int{1, 2}, lastId: "a"},
+		"a": {items: []int{3, 4}, lastId: "b"},
+	}
+
+	it := NewIterator(context.Background(), "", pagedFetcher(pages, &calls), &IteratorOptions{MaxItems: 3})
+	items, err := it.Collect()
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, items)
+	assert.Equal(t, []string{"", "a"}, calls)
+	assert.Equal(t, "b", it.LastId())
+}
+
+func TestIterator_Error(t *testing.T) {
+	var calls []string
+	pages := map[string]page{
+		"": {items: []int{1}, lastId: "missing"},
+	}
+
+	it := NewIterator(context.Background(), "", pagedFetcher(pages, &calls), nil)
+	items, err := it.Collect()
+
+	assert.EqualError(t, err, "unknown page missing")
+	assert.Equal(t, []int{1}, items)
+	assert.False(t, it.Next())
+}
+
+func TestIterator_StuckCursor(t *testing.T) {
+	var calls []string
+	pages := map[string]page{
+		"":  {items: []int{1}, lastId: "a"},
+		"a": {items: []int{2}, lastId: "a"},
+	}
+
+	items, err := NewIterator(context.Background(), "", pagedFetcher(pages, &calls), nil).Collect()
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, items)
+	assert.Equal(t, []string{"", "a"}, calls)
+}
+
+func TestIterator_ContextCanceled(t *testing.T) {
+	var calls []string
+	pages := map[string]page{
+		"": {items: []int{1}, lastId: "a"},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+
+	it := NewIterator(ctx, "", pagedFetcher(pages, &calls), nil)
+	assert.True(t, it.Next())
+	cancel()
+	assert.False(t, it.Next())
+	assert.ErrorIs(t, it.Err(), context.Canceled)
+	assert.Equal(t, []string{""}, calls)
+}
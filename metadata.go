@@ -1,7 +1,15 @@
 package onfleet
 
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
 // Onfleet Metadata.
 // Reference https://docs.onfleet.com/reference/metadata
+//
+// The metadata package builds entries with a Value matching their Type.
 type Metadata struct {
 	Name       string                     `json:"name"`
 	Subtype    string                     `json:"subtype,omitempty"`
@@ -10,6 +18,16 @@ type Metadata struct {
 	Visibility []MetadataVisibilityOption `json:"visibility,omitempty"`
 }
 
+// Metadata types. Subtype is only set for MetadataTypeArray and is one of
+// the other types.
+const (
+	MetadataTypeArray   = "array"
+	MetadataTypeBoolean = "boolean"
+	MetadataTypeNumber  = "number"
+	MetadataTypeObject  = "object"
+	MetadataTypeString  = "string"
+)
+
 type MetadataVisibilityOption string
 
 const (
@@ -17,3 +35,101 @@ const (
 	MetadataVisibilityOptionDashboard MetadataVisibilityOption = "dashboard"
 	MetadataVisibilityOptionWorker    MetadataVisibilityOption = "worker"
 )
+
+// WithVisibility returns m visible to only the given options.
+// No options leaves the API default, visible to the api only.
+func (m Metadata) WithVisibility(options ...MetadataVisibilityOption) Metadata {
+	m.Visibility = options
+	return m
+}
+
+// VisibleTo reports whether m is visible to option. Entries without
+// visibility are visible to the api only.
+func (m Metadata) VisibleTo(option MetadataVisibilityOption) bool {
+	if len(m.Visibility) == 0 {
+		return option == MetadataVisibilityOptionApi
+	}
+	for _, visibility := range m.Visibility {
+		if visibility == option {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks the Type, Subtype and Visibility are known and that Value
+// is of Type, problems the API would otherwise reject.
+func (m Metadata) Validate() error {
+	if m.Name == "" {
+		return errors.New("name is required")
+	}
+	for _, visibility := range m.Visibility {
+		switch visibility {
+		case MetadataVisibilityOptionApi, MetadataVisibilityOptionDashboard, MetadataVisibilityOptionWorker:
+		default:
+			return fmt.Errorf("%s: unknown visibility %q", m.Name, visibility)
+		}
+	}
+	switch m.Type {
+	case MetadataTypeArray:
+		if !validMetadataSubtype(m.Subtype) {
+			return fmt.Errorf("%s: unknown array subtype %q", m.Name, m.Subtype)
+		}
+		value := reflect.ValueOf(m.Value)
+		if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+			return fmt.Errorf("%s: value of type array must be a slice, got %T", m.Name, m.Value)
+		}
+		for i := 0; i < value.Len(); i++ {
+			if !isMetadataValue(m.Subtype, value.Index(i).Interface()) {
+				return fmt.Errorf("%s[%d]: value must be a %s, got %T", m.Name, i, m.Subtype, value.Index(i).Interface())
+			}
+		}
+		return nil
+	case MetadataTypeBoolean, MetadataTypeNumber, MetadataTypeObject, MetadataTypeString:
+		if m.Subtype != "" {
+			return fmt.Errorf("%s: subtype is only allowed for type array", m.Name)
+		}
+		if !isMetadataValue(m.Type, m.Value) {
+			return fmt.Errorf("%s: value of type %s must be a %s, got %T", m.Name, m.Type, m.Type, m.Value)
+		}
+		return nil
+	}
+	return fmt.Errorf("%s: unknown type %q", m.Name, m.Type)
+}
+
+func validMetadataSubtype(subtype string) bool {
+	switch subtype {
+	case MetadataTypeBoolean, MetadataTypeNumber, MetadataTypeObject, MetadataTypeString:
+		return true
+	}
+	return false
+}
+
+// isMetadataValue reports whether v, as built in Go or decoded from json,
+// is a value of the scalar or object metadata type t.
+func isMetadataValue(t string, v any) bool {
+	value := reflect.ValueOf(v)
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return false
+		}
+		value = value.Elem()
+	}
+	switch t {
+	case MetadataTypeBoolean:
+		return value.Kind() == reflect.Bool
+	case MetadataTypeString:
+		return value.Kind() == reflect.String
+	case MetadataTypeNumber:
+		switch value.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+			return true
+		}
+	case MetadataTypeObject:
+		return value.Kind() == reflect.Struct ||
+			value.Kind() == reflect.Map && value.Type().Key().Kind() == reflect.String
+	}
+	return false
+}
@@ -0,0 +1,245 @@
+// Package metadata builds and reads onfleet.Metadata entries with values
+// matching their type, and maps them to and from Go structs.
+// Reference https://docs.onfleet.com/reference/metadata
+package metadata
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/onfleet/gonfleet"
+)
+
+// Numeric is the Go types of a number entry.
+type Numeric interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64
+}
+
+// Element is the Go types of an array entry element, their subtype is
+// "boolean", "number", "string" and "object" respectively.
+type Element interface {
+	Numeric | ~bool | ~string | map[string]any
+}
+
+// String builds a string entry.
+func String(name string, value string, visibility ...onfleet.MetadataVisibilityOption) onfleet.Metadata {
+	return entry(name, onfleet.MetadataTypeString, "", value, visibility)
+}
+
+// Number builds a number entry. The value is sent as a float64, like every
+// json number, so integers beyond ±2^53 lose precision; store such values,
+// e.g. external ids, as a String.
+func Number[T Numeric](name string, value T, visibility ...onfleet.MetadataVisibilityOption) onfleet.Metadata {
+	return entry(name, onfleet.MetadataTypeNumber, "", float64(value), visibility)
+}
+
+// Boolean builds a boolean entry.
+func Boolean(name string, value bool, visibility ...onfleet.MetadataVisibilityOption) onfleet.Metadata {
+	return entry(name, onfleet.MetadataTypeBoolean, "", value, visibility)
+}
+
+// Object builds an object entry.
+func Object(name string, value map[string]any, visibility ...onfleet.MetadataVisibilityOption) onfleet.Metadata {
+	return entry(name, onfleet.MetadataTypeObject, "", value, visibility)
+}
+
+// Array builds an array entry whose subtype follows from the element type,
+// e.g. Array("tags", []string{"fragile"}) has subtype "string".
+func Array[T Element](name string, values []T, visibility ...onfleet.MetadataVisibilityOption) onfleet.Metadata {
+	var zero T
+	subtype := subtypeOf(any(zero))
+	items := make([]any, len(values))
+	for i, value := range values {
+		items[i] = normalize(any(value))
+	}
+	return entry(name, onfleet.MetadataTypeArray, subtype, items, visibility)
+}
+
+func entry(name string, t string, subtype string, value any, visibility []onfleet.MetadataVisibilityOption) onfleet.Metadata {
+	m := onfleet.Metadata{Name: name, Type: t, Subtype: subtype, Value: value}
+	if len(visibility) > 0 {
+		m = m.WithVisibility(visibility...)
+	}
+	return m
+}
+
+// subtypeOf returns the subtype of an Element.
+func subtypeOf(v any) string {
+	switch normalize(v).(type) {
+	case bool:
+		return onfleet.MetadataTypeBoolean
+	case float64:
+		return onfleet.MetadataTypeNumber
+	case string:
+		return onfleet.MetadataTypeString
+	}
+	return onfleet.MetadataTypeObject
+}
+
+// normalize converts an Element to the value json decoding gives, so that
+// built and decoded entries read the same.
+func normalize(v any) any {
+	value, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+	var decoded any
+	if json.Unmarshal(value, &decoded) != nil {
+		return v
+	}
+	if decoded == nil {
+		// nil maps
+		return map[string]any{}
+	}
+	return decoded
+}
+
+// List is the metadata of a task, worker, destination or recipient, read
+// with typed getters, e.g. metadata.List(task.Metadata).GetString("gate").
+type List []onfleet.Metadata
+
+// Get returns the entry named name.
+func (l List) Get(name string) (onfleet.Metadata, bool) {
+	for _, m := range l {
+		if m.Name == name {
+			return m, true
+		}
+	}
+	return onfleet.Metadata{}, false
+}
+
+// Set returns a copy of l with the entry of the same name replaced, or m
+// appended. l is left unchanged.
+func (l List) Set(m onfleet.Metadata) List {
+	set := make(List, len(l), len(l)+1)
+	copy(set, l)
+	for i := range set {
+		if set[i].Name == m.Name {
+			set[i] = m
+			return set
+		}
+	}
+	return append(set, m)
+}
+
+// Delete returns a copy of l without the entry named name. l is left
+// unchanged.
+func (l List) Delete(name string) List {
+	kept := make(List, 0, len(l))
+	for _, m := range l {
+		if m.Name != name {
+			kept = append(kept, m)
+		}
+	}
+	return kept
+}
+
+// Visible returns the entries visible to option, e.g. to the worker app.
+func (l List) Visible(option onfleet.MetadataVisibilityOption) List {
+	var visible List
+	for _, m := range l {
+		if m.VisibleTo(option) {
+			visible = append(visible, m)
+		}
+	}
+	return visible
+}
+
+// Validate checks every entry with onfleet.Metadata.Validate and that
+// names are unique.
+func (l List) Validate() error {
+	seen := map[string]bool{}
+	for _, m := range l {
+		if err := m.Validate(); err != nil {
+			return err
+		}
+		if seen[m.Name] {
+			return fmt.Errorf("%s: duplicate name", m.Name)
+		}
+		seen[m.Name] = true
+	}
+	return nil
+}
+
+// GetString returns the value of the string entry named name.
+// ok is false if there is none or it is of another type.
+func (l List) GetString(name string) (value string, ok bool) {
+	err := l.get(name, onfleet.MetadataTypeString, "", &value)
+	return value, err == nil
+}
+
+// GetNumber returns the value of the number entry named name.
+func (l List) GetNumber(name string) (value float64, ok bool) {
+	err := l.get(name, onfleet.MetadataTypeNumber, "", &value)
+	return value, err == nil
+}
+
+// GetBoolean returns the value of the boolean entry named name.
+func (l List) GetBoolean(name string) (value bool, ok bool) {
+	err := l.get(name, onfleet.MetadataTypeBoolean, "", &value)
+	return value, err == nil
+}
+
+// GetObject returns the value of the object entry named name.
+func (l List) GetObject(name string) (value map[string]any, ok bool) {
+	err := l.get(name, onfleet.MetadataTypeObject, "", &value)
+	return value, err == nil
+}
+
+// GetStrings returns the values of the string array entry named name.
+func (l List) GetStrings(name string) (values []string, ok bool) {
+	err := l.get(name, onfleet.MetadataTypeArray, onfleet.MetadataTypeString, &values)
+	return values, err == nil
+}
+
+// GetNumbers returns the values of the number array entry named name.
+func (l List) GetNumbers(name string) (values []float64, ok bool) {
+	err := l.get(name, onfleet.MetadataTypeArray, onfleet.MetadataTypeNumber, &values)
+	return values, err == nil
+}
+
+// GetBooleans returns the values of the boolean array entry named name.
+func (l List) GetBooleans(name string) (values []bool, ok bool) {
+	err := l.get(name, onfleet.MetadataTypeArray, onfleet.MetadataTypeBoolean, &values)
+	return values, err == nil
+}
+
+// GetObjects returns the values of the object array entry named name.
+func (l List) GetObjects(name string) (values []map[string]any, ok bool) {
+	err := l.get(name, onfleet.MetadataTypeArray, onfleet.MetadataTypeObject, &values)
+	return values, err == nil
+}
+
+// get decodes the value of the entry named name, which must be of type t
+// and subtype, into v.
+func (l List) get(name string, t string, subtype string, v any) error {
+	m, ok := l.Get(name)
+	if !ok {
+		return fmt.Errorf("%s: not found", name)
+	}
+	if m.Type != t || m.Subtype != subtype {
+		return fmt.Errorf("%s: is of type %s, not %s", name, typeName(m.Type, m.Subtype), typeName(t, subtype))
+	}
+	if err := m.Validate(); err != nil {
+		return err
+	}
+	return decodeValue(m.Value, v)
+}
+
+func typeName(t string, subtype string) string {
+	if subtype == "" {
+		return t
+	}
+	return t + " of " + subtype
+}
+
+// decodeValue converts a metadata value to the Go value pointed to by v.
+func decodeValue(value any, v any) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
@@ -0,0 +1,137 @@
+package metadata
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onfleet/gonfleet"
+)
+
+func TestConstructors(t *testing.T) {
+	tests := []struct {
+		name  string
+		entry onfleet.Metadata
+		want  string
+	}{
+		{"string", String("gate", "1234"), `{"name":"gate","type":"string","value":"1234"}`},
+		{"number", Number("floor", 3), `{"name":"floor","type":"number","value":3}`},
+		{"boolean", Boolean("fragile", true, onfleet.MetadataVisibilityOptionWorker), `{"name":"fragile","type":"boolean","value":true,"visibility":["worker"]}`},
+		{"object", Object("dims", map[string]any{"w": 2}), `{"name":"dims","type":"object","value":{"w":2}}`},
+		{"string array", Array("tags", []string{"a", "b"}), `{"name":"tags","type":"array","subtype":"string","value":["a","b"]}`},
+		{"number array", Array("sizes", []int{1, 2}), `{"name":"sizes","type":"array","subtype":"number","value":[1,2]}`},
+		{"object array", Array("items", []map[string]any{{"sku": "x"}}), `{"name":"items","type":"array","subtype":"object","value":[{"sku":"x"}]}`},
+		{"empty array", Array("none", []bool{}), `{"name":"none","type":"array","subtype":"boolean","value":[]}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.NoError(t, tt.entry.Validate())
+			data, err := json.Marshal(tt.entry)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(data))
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		entry onfleet.Metadata
+		err   string
+	}{
+		{"number as string", onfleet.Metadata{Name: "n", Type: "number", Value: "3"}, "must be a number"},
+		{"string as number", onfleet.Metadata{Name: "s", Type: "string", Value: 3.0}, "must be a string"},
+		{"array subtype", onfleet.Metadata{Name: "a", Type: "array", Subtype: "string", Value: []any{"a", 1.0}}, "a[1]: value must be a string"},
+		{"missing subtype", onfleet.Metadata{Name: "a", Type: "array", Value: []any{}}, "unknown array subtype"},
+		{"scalar subtype", onfleet.Metadata{Name: "s", Type: "string", Subtype: "string", Value: "x"}, "only allowed for type array"},
+		{"unknown type", onfleet.Metadata{Name: "d", Type: "date", Value: "x"}, "unknown type"},
+		{"visibility", onfleet.Metadata{Name: "s", Type: "string", Value: "x", Visibility: []onfleet.MetadataVisibilityOption{"everyone"}}, "unknown visibility"},
+		{"name", onfleet.Metadata{Type: "string", Value: "x"}, "name is required"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorContains(t, tt.entry.Validate(), tt.err)
+		})
+	}
+
+	assert.ErrorContains(t, List{String("a", "x"), Number("a", 1)}.Validate(), "duplicate")
+
+	err := onfleet.TaskParams{Metadata: []onfleet.Metadata{{Name: "n", Type: "number", Value: "3"}}}.Validate()
+	assert.ErrorContains(t, err, "metadata[0]: n: value of type number")
+}
+
+func TestList(t *testing.T) {
+	var decoded []onfleet.Metadata
+	require.NoError(t, json.Unmarshal([]byte(`[
+		{"name":"gate","type":"string","value":"1234","visibility":["worker","dashboard"]},
+		{"name":"floor","type":"number","value":3},
+		{"name":"fragile","type":"boolean","value":true},
+		{"name":"dims","type":"object","value":{"w":2}},
+		{"name":"tags","type":"array","subtype":"string","value":["a","b"]},
+		{"name":"sizes","type":"array","subtype":"number","value":[1.5]}
+	]`), &decoded))
+	list := List(decoded)
+
+	gate, ok := list.GetString("gate")
+	assert.True(t, ok)
+	assert.Equal(t, "1234", gate)
+	floor, ok := list.GetNumber("floor")
+	assert.True(t, ok)
+	assert.Equal(t, 3.0, floor)
+	fragile, ok := list.GetBoolean("fragile")
+	assert.True(t, ok)
+	assert.True(t, fragile)
+	dims, ok := list.GetObject("dims")
+	assert.True(t, ok)
+	assert.Equal(t, map[string]any{"w": 2.0}, dims)
+	tags, ok := list.GetStrings("tags")
+	assert.True(t, ok)
+	assert.Equal(t, []string{"a", "b"}, tags)
+	sizes, ok := list.GetNumbers("sizes")
+	assert.True(t, ok)
+	assert.Equal(t, []float64{1.5}, sizes)
+
+	_, ok = list.GetNumber("gate")
+	assert.False(t, ok, "wrong type")
+	_, ok = list.GetString("missing")
+	assert.False(t, ok)
+	_, ok = list.GetBooleans("tags")
+	assert.False(t, ok, "wrong subtype")
+
+	// built entries read the same as decoded ones
+	built := List{Number("floor", int64(3)), Array("sizes", []float32{1.5})}
+	floor, _ = built.GetNumber("floor")
+	assert.Equal(t, 3.0, floor)
+	sizes, _ = built.GetNumbers("sizes")
+	assert.Equal(t, []float64{1.5}, sizes)
+
+	assert.Len(t, list.Visible(onfleet.MetadataVisibilityOptionWorker), 1)
+	assert.Len(t, list.Visible(onfleet.MetadataVisibilityOptionApi), 5)
+
+	list = list.Set(String("gate", "9999"))
+	gate, _ = list.GetString("gate")
+	assert.Equal(t, "9999", gate)
+	list = list.Set(String("buzzer", "2B")).Delete("floor")
+	_, ok = list.Get("floor")
+	assert.False(t, ok)
+	assert.Len(t, list, 6)
+
+	// the decoded entries are not changed by Set or Delete
+	gate, _ = List(decoded).GetString("gate")
+	assert.Equal(t, "1234", gate)
+	_, ok = List(decoded).Get("floor")
+	assert.True(t, ok)
+	assert.Equal(t, "floor", decoded[1].Name)
+}
+
+func TestVisibility(t *testing.T) {
+	m := String("gate", "1234")
+	assert.True(t, m.VisibleTo(onfleet.MetadataVisibilityOptionApi))
+	assert.False(t, m.VisibleTo(onfleet.MetadataVisibilityOptionWorker))
+
+	m = m.WithVisibility(onfleet.MetadataVisibilityOptionWorker)
+	assert.True(t, m.VisibleTo(onfleet.MetadataVisibilityOptionWorker))
+	assert.False(t, m.VisibleTo(onfleet.MetadataVisibilityOptionApi))
+}
@@ -0,0 +1,188 @@
+package metadata
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/onfleet/gonfleet"
+)
+
+// Query builds the body of a metadata query, matching the entities which
+// have every entry by name, type, subtype and value. Problems are reported
+// by Build.
+//
+//	query := metadata.NewQuery().String("region", "north").Boolean("vip", true)
+type Query struct {
+	entries List
+	errs    []error
+}
+
+// NewQuery starts an empty query.
+func NewQuery() *Query {
+	return &Query{}
+}
+
+// Where adds entries, e.g. built with Array.
+func (q *Query) Where(entries ...onfleet.Metadata) *Query {
+	for _, m := range entries {
+		if _, ok := q.entries.Get(m.Name); ok {
+			q.errs = append(q.errs, fmt.Errorf("%s: queried more than once", m.Name))
+			continue
+		}
+		if len(m.Visibility) > 0 {
+			q.errs = append(q.errs, fmt.Errorf("%s: visibility can not be queried", m.Name))
+			continue
+		}
+		if err := m.Validate(); err != nil {
+			q.errs = append(q.errs, err)
+			continue
+		}
+		q.entries = append(q.entries, m)
+	}
+	return q
+}
+
+// String matches a string entry.
+func (q *Query) String(name string, value string) *Query {
+	return q.Where(String(name, value))
+}
+
+// Number matches a number entry.
+func (q *Query) Number(name string, value float64) *Query {
+	return q.Where(Number(name, value))
+}
+
+// Boolean matches a boolean entry.
+func (q *Query) Boolean(name string, value bool) *Query {
+	return q.Where(Boolean(name, value))
+}
+
+// Object matches an object entry.
+func (q *Query) Object(name string, value map[string]any) *Query {
+	return q.Where(Object(name, value))
+}
+
+// Build returns the query body, or every problem found while building it.
+func (q *Query) Build() ([]onfleet.Metadata, error) {
+	errs := q.errs
+	if len(q.entries) == 0 && len(errs) == 0 {
+		errs = append(errs, errors.New("no entries"))
+	}
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("metadata query: %w", errors.Join(errs...))
+	}
+	return append([]onfleet.Metadata(nil), q.entries...), nil
+}
+
+// Lister is a service which lists its entities by metadata, e.g.
+// client.Tasks or client.Workers.
+type Lister[T any] interface {
+	ListWithMetadataQueryCtx(ctx context.Context, metadata []onfleet.Metadata) ([]T, error)
+}
+
+// FindOptions configures Find and FindAll.
+type FindOptions struct {
+	// MaxItems stops after this many results per entity type. 0 means no limit.
+	MaxItems int
+}
+
+// Find runs query against lister. The API answers a metadata query with
+// every match in a single response.
+func Find[T any](ctx context.Context, lister Lister[T], query *Query, opts *FindOptions) ([]T, error) {
+	body, err := query.Build()
+	if err != nil {
+		return nil, err
+	}
+	results, err := lister.ListWithMetadataQueryCtx(ctx, body)
+	if err != nil {
+		return nil, err
+	}
+	if opts != nil && opts.MaxItems > 0 && len(results) > opts.MaxItems {
+		results = results[:opts.MaxItems]
+	}
+	return results, nil
+}
+
+// Sources are the services FindAll queries, nil ones are skipped.
+type Sources struct {
+	Administrators Lister[onfleet.Admin]
+	Destinations   Lister[onfleet.Destination]
+	Recipients     Lister[onfleet.Recipient]
+	Tasks          Lister[onfleet.Task]
+	Workers        Lister[onfleet.Worker]
+}
+
+// Results are the entities found by FindAll.
+type Results struct {
+	Administrators []onfleet.Admin
+	Destinations   []onfleet.Destination
+	Recipients     []onfleet.Recipient
+	Tasks          []onfleet.Task
+	Workers        []onfleet.Worker
+}
+
+// FindAll runs the same query against every source concurrently, e.g.
+//
+//	results, err := metadata.FindAll(ctx, query, metadata.Sources{
+//		Tasks:   api.Tasks,
+//		Workers: api.Workers,
+//	}, nil)
+//
+// The results of the sources which succeeded are returned along with the
+// errors of those which failed.
+func FindAll(ctx context.Context, query *Query, sources Sources, opts *FindOptions) (Results, error) {
+	if _, err := query.Build(); err != nil {
+		return Results{}, err
+	}
+	var (
+		results Results
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		errs    []error
+	)
+	run := func(name string, find func() error) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := find(); err != nil {
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("%s: %w", name, err))
+				mu.Unlock()
+			}
+		}()
+	}
+	if sources.Administrators != nil {
+		run("administrators", func() (err error) {
+			results.Administrators, err = Find(ctx, sources.Administrators, query, opts)
+			return err
+		})
+	}
+	if sources.Destinations != nil {
+		run("destinations", func() (err error) {
+			results.Destinations, err = Find(ctx, sources.Destinations, query, opts)
+			return err
+		})
+	}
+	if sources.Recipients != nil {
+		run("recipients", func() (err error) {
+			results.Recipients, err = Find(ctx, sources.Recipients, query, opts)
+			return err
+		})
+	}
+	if sources.Tasks != nil {
+		run("tasks", func() (err error) {
+			results.Tasks, err = Find(ctx, sources.Tasks, query, opts)
+			return err
+		})
+	}
+	if sources.Workers != nil {
+		run("workers", func() (err error) {
+			results.Workers, err = Find(ctx, sources.Workers, query, opts)
+			return err
+		})
+	}
+	wg.Wait()
+	return results, errors.Join(errs...)
+}
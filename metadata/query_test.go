@@ -0,0 +1,120 @@
+package metadata
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onfleet/gonfleet"
+	"github.com/onfleet/gonfleet/service/task"
+	"github.com/onfleet/gonfleet/service/worker"
+	"github.com/onfleet/gonfleet/testingutil"
+)
+
+func TestQuery_Build(t *testing.T) {
+	body, err := NewQuery().
+		String("region", "north").
+		Number("floor", 3).
+		Boolean("vip", true).
+		Where(Array("tags", []string{"a"})).
+		Build()
+	require.NoError(t, err)
+	assert.Equal(t, []onfleet.Metadata{
+		String("region", "north"),
+		Number("floor", 3),
+		Boolean("vip", true),
+		Array("tags", []string{"a"}),
+	}, body)
+
+	_, err = NewQuery().Build()
+	assert.ErrorContains(t, err, "no entries")
+
+	_, err = NewQuery().
+		String("region", "north").
+		String("region", "south").
+		Where(onfleet.Metadata{Name: "floor", Type: "number", Value: "3"}).
+		Where(String("gate", "1", onfleet.MetadataVisibilityOptionWorker)).
+		Build()
+	assert.ErrorContains(t, err, "region: queried more than once")
+	assert.ErrorContains(t, err, "floor: value of type number")
+	assert.ErrorContains(t, err, "gate: visibility can not be queried")
+}
+
+func tasksWithIds(ids ...string) []onfleet.Task {
+	tasks := make([]onfleet.Task, len(ids))
+	for i, id := range ids {
+		tasks[i] = onfleet.Task{ID: id}
+	}
+	return tasks
+}
+
+func taskIds(tasks []onfleet.Task) []string {
+	ids := make([]string, len(tasks))
+	for i, task := range tasks {
+		ids[i] = task.ID
+	}
+	return ids
+}
+
+func TestFind(t *testing.T) {
+	// a response of any size, e.g. 64 results, is taken as complete
+	ids := make([]string, 64)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("t%02d", i)
+	}
+	caller := &testingutil.PaginatedCaller{Pages: map[string]interface{}{
+		"": tasksWithIds(ids...),
+	}}
+	client := task.Plug("test_api_key", nil, "https://api.example.com/tasks", caller.Call)
+
+	tasks, err := Find[onfleet.Task](context.Background(), client, NewQuery().String("region", "north"), nil)
+	require.NoError(t, err)
+	assert.Equal(t, ids, taskIds(tasks))
+	assert.Equal(t, []string{""}, caller.LastIds, "a single request")
+}
+
+func TestFind_Options(t *testing.T) {
+	caller := &testingutil.PaginatedCaller{Pages: map[string]interface{}{
+		"": tasksWithIds("a", "b", "c", "d"),
+	}}
+	client := task.Plug("test_api_key", nil, "https://api.example.com/tasks", caller.Call)
+	query := NewQuery().String("region", "north")
+
+	tasks, err := Find[onfleet.Task](context.Background(), client, query, &FindOptions{MaxItems: 3})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "c"}, taskIds(tasks))
+
+	tasks, err = Find[onfleet.Task](context.Background(), client, query, &FindOptions{MaxItems: 10})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "c", "d"}, taskIds(tasks))
+
+	_, err = Find[onfleet.Task](context.Background(), client, NewQuery(), nil)
+	assert.ErrorContains(t, err, "no entries")
+}
+
+// failingLister is a Lister whose query always fails.
+type failingLister struct{}
+
+func (failingLister) ListWithMetadataQueryCtx(ctx context.Context, metadata []onfleet.Metadata) ([]onfleet.Recipient, error) {
+	return nil, errors.New("boom")
+}
+
+func TestFindAll(t *testing.T) {
+	taskCaller := &testingutil.PaginatedCaller{Pages: map[string]interface{}{"": tasksWithIds("t1")}}
+	workerCaller := &testingutil.PaginatedCaller{Pages: map[string]interface{}{"": []onfleet.Worker{{ID: "w1"}, {ID: "w2"}}}}
+
+	results, err := FindAll(context.Background(), NewQuery().String("region", "north"), Sources{
+		Tasks:      task.Plug("test_api_key", nil, "https://api.example.com/tasks", taskCaller.Call),
+		Workers:    worker.Plug("test_api_key", nil, "https://api.example.com/workers", workerCaller.Call),
+		Recipients: failingLister{},
+	}, nil)
+	assert.ErrorContains(t, err, "recipients: boom")
+	assert.Equal(t, []string{"t1"}, taskIds(results.Tasks))
+	assert.Len(t, results.Workers, 2)
+	assert.Nil(t, results.Recipients)
+	assert.Nil(t, results.Destinations)
+}
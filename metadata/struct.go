@@ -0,0 +1,181 @@
+package metadata
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/onfleet/gonfleet"
+)
+
+// field is a struct field mapped to a metadata entry.
+type field struct {
+	index      int
+	name       string
+	omitEmpty  bool
+	visibility []onfleet.MetadataVisibilityOption
+}
+
+// fields returns the mapped fields of struct type t, named by their
+// `metadata` tag or else the field name. The tag options are omitempty
+// and the visibility options api, dashboard and worker, e.g.
+// `metadata:"gateCode,omitempty,worker,dashboard"`.
+func fields(t reflect.Type) ([]field, error) {
+	var mapped []field
+	for i := 0; i < t.NumField(); i++ {
+		structField := t.Field(i)
+		if !structField.IsExported() {
+			continue
+		}
+		tag := structField.Tag.Get("metadata")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+		f := field{index: i, name: name}
+		if f.name == "" {
+			f.name = structField.Name
+		}
+		for _, option := range strings.Split(options, ",") {
+			switch onfleet.MetadataVisibilityOption(option) {
+			case "":
+			case "omitempty":
+				f.omitEmpty = true
+			case onfleet.MetadataVisibilityOptionApi, onfleet.MetadataVisibilityOptionDashboard, onfleet.MetadataVisibilityOptionWorker:
+				f.visibility = append(f.visibility, onfleet.MetadataVisibilityOption(option))
+			default:
+				return nil, fmt.Errorf("metadata: field %s: unknown tag option %q", structField.Name, option)
+			}
+		}
+		mapped = append(mapped, f)
+	}
+	return mapped, nil
+}
+
+// structValue dereferences v to the struct it points to.
+func structValue(v any, settable bool) (reflect.Value, error) {
+	value := reflect.ValueOf(v)
+	if settable {
+		if value.Kind() != reflect.Pointer || value.IsNil() {
+			return value, fmt.Errorf("metadata: Unmarshal needs a non nil pointer to a struct, got %T", v)
+		}
+	}
+	for value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return value, fmt.Errorf("metadata: nil %T", v)
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return value, fmt.Errorf("metadata: struct expected, got %T", v)
+	}
+	return value, nil
+}
+
+// Marshal maps the fields of struct v to metadata entries, their type
+// following from the field type: strings, numbers and booleans, maps with
+// string keys and structs as objects, and slices of these as arrays.
+// Nil pointers are skipped, as are zero values of omitempty fields.
+func Marshal(v any) (List, error) {
+	value, err := structValue(v, false)
+	if err != nil {
+		return nil, err
+	}
+	mapped, err := fields(value.Type())
+	if err != nil {
+		return nil, err
+	}
+	var list List
+	for _, f := range mapped {
+		fieldValue := value.Field(f.index)
+		if f.omitEmpty && fieldValue.IsZero() {
+			continue
+		}
+		for fieldValue.Kind() == reflect.Pointer || fieldValue.Kind() == reflect.Interface {
+			if fieldValue.IsNil() {
+				break
+			}
+			fieldValue = fieldValue.Elem()
+		}
+		if (fieldValue.Kind() == reflect.Pointer || fieldValue.Kind() == reflect.Interface) && fieldValue.IsNil() {
+			continue
+		}
+		t, subtype, err := typeOf(fieldValue.Type())
+		if err != nil {
+			return nil, fmt.Errorf("metadata: field %s: %w", f.name, err)
+		}
+		m := entry(f.name, t, subtype, normalize(fieldValue.Interface()), f.visibility)
+		if t == onfleet.MetadataTypeArray && fieldValue.Len() == 0 {
+			// nil slices decode as null
+			m.Value = []any{}
+		}
+		if err := m.Validate(); err != nil {
+			return nil, fmt.Errorf("metadata: field %w", err)
+		}
+		list = append(list, m)
+	}
+	return list, nil
+}
+
+// Unmarshal sets the fields of the struct v points to from the entries of
+// the same name. Fields without an entry are left unchanged, an entry of
+// another type than the field is an error.
+func Unmarshal(list []onfleet.Metadata, v any) error {
+	value, err := structValue(v, true)
+	if err != nil {
+		return err
+	}
+	mapped, err := fields(value.Type())
+	if err != nil {
+		return err
+	}
+	for _, f := range mapped {
+		fieldValue := value.Field(f.index)
+		fieldType := fieldValue.Type()
+		for fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+		t, subtype, err := typeOf(fieldType)
+		if err != nil {
+			return fmt.Errorf("metadata: field %s: %w", f.name, err)
+		}
+		if _, ok := List(list).Get(f.name); !ok {
+			continue
+		}
+		if err := List(list).get(f.name, t, subtype, fieldValue.Addr().Interface()); err != nil {
+			return fmt.Errorf("metadata: field %w", err)
+		}
+	}
+	return nil
+}
+
+// typeOf returns the metadata type and subtype of Go type t.
+func typeOf(t reflect.Type) (string, string, error) {
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		elem := t.Elem()
+		for elem.Kind() == reflect.Pointer {
+			elem = elem.Elem()
+		}
+		subtype, _, err := typeOf(elem)
+		if err != nil || subtype == onfleet.MetadataTypeArray {
+			return "", "", fmt.Errorf("unsupported array element type %s", t.Elem())
+		}
+		return onfleet.MetadataTypeArray, subtype, nil
+	case reflect.String:
+		return onfleet.MetadataTypeString, "", nil
+	case reflect.Bool:
+		return onfleet.MetadataTypeBoolean, "", nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return onfleet.MetadataTypeNumber, "", nil
+	case reflect.Struct:
+		return onfleet.MetadataTypeObject, "", nil
+	case reflect.Map:
+		if t.Key().Kind() == reflect.String {
+			return onfleet.MetadataTypeObject, "", nil
+		}
+	}
+	return "", "", fmt.Errorf("unsupported type %s", t)
+}
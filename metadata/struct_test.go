@@ -0,0 +1,99 @@
+package metadata
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onfleet/gonfleet"
+)
+
+type dimensions struct {
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+}
+
+type delivery struct {
+	GateCode  string            `metadata:"gateCode,worker,dashboard"`
+	Floor     int               `metadata:"floor"`
+	Fragile   bool              `metadata:"fragile,omitempty"`
+	Tags      []string          `metadata:"tags"`
+	Size      dimensions        `metadata:"size"`
+	Extra     map[string]string `metadata:"extra,omitempty"`
+	Signature *string           `metadata:"signature"`
+	Internal  string            `metadata:"-"`
+	Untagged  string
+}
+
+func TestMarshal(t *testing.T) {
+	list, err := Marshal(delivery{
+		GateCode: "1234",
+		Floor:    3,
+		Size:     dimensions{Width: 2, Height: 1.5},
+		Internal: "secret",
+		Untagged: "x",
+	})
+	require.NoError(t, err)
+	data, err := json.Marshal(list)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[
+		{"name":"gateCode","type":"string","value":"1234","visibility":["worker","dashboard"]},
+		{"name":"floor","type":"number","value":3},
+		{"name":"tags","type":"array","subtype":"string","value":[]},
+		{"name":"size","type":"object","value":{"width":2,"height":1.5}},
+		{"name":"Untagged","type":"string","value":"x"}
+	]`, string(data))
+	assert.NoError(t, list.Validate())
+}
+
+func TestUnmarshal(t *testing.T) {
+	signature := "J. Doe"
+	list, err := Marshal(&delivery{
+		GateCode:  "1234",
+		Floor:     3,
+		Fragile:   true,
+		Tags:      []string{"a"},
+		Size:      dimensions{Width: 2},
+		Extra:     map[string]string{"k": "v"},
+		Signature: &signature,
+	})
+	require.NoError(t, err)
+
+	var decoded []onfleet.Metadata
+	data, err := json.Marshal(list)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(data, &decoded))
+
+	got := delivery{Internal: "kept", Untagged: "kept"}
+	require.NoError(t, Unmarshal(decoded, &got))
+	assert.Equal(t, delivery{
+		GateCode:  "1234",
+		Floor:     3,
+		Fragile:   true,
+		Tags:      []string{"a"},
+		Size:      dimensions{Width: 2},
+		Extra:     map[string]string{"k": "v"},
+		Signature: &signature,
+		Internal:  "kept",
+	}, got)
+}
+
+func TestUnmarshal_Errors(t *testing.T) {
+	var got delivery
+	err := Unmarshal([]onfleet.Metadata{String("floor", "3")}, &got)
+	assert.ErrorContains(t, err, "floor: is of type string, not number")
+
+	assert.Error(t, Unmarshal(nil, got))
+
+	_, err = Marshal(struct {
+		Ch chan int `metadata:"ch"`
+	}{})
+	assert.ErrorContains(t, err, "unsupported type")
+
+	_, err = Marshal(struct {
+		S string `metadata:"s,everyone"`
+	}{})
+	assert.ErrorContains(t, err, "unknown tag option")
+}
@@ -0,0 +1,97 @@
+package onfleet
+
+import "time"
+
+// Millis is a unix epoch time in milliseconds, the representation of
+// every timestamp in the Onfleet API. It encodes to and from json as the
+// plain number. The int64 timestamp fields of the models convert with
+// Millis(task.TimeCreated).Time() or accessors such as Task.ETATime.
+type Millis int64
+
+// FromTime converts t to Millis. The zero time converts to 0, which
+// omitempty params fields leave unset. Set an int64 field with
+// int64(FromTime(t)).
+func FromTime(t time.Time) Millis {
+	if t.IsZero() {
+		return 0
+	}
+	return Millis(t.UnixMilli())
+}
+
+// Time returns m as a local time.Time, the zero time for 0.
+func (m Millis) Time() time.Time {
+	if m == 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(int64(m))
+}
+
+// IsZero reports whether m is unset.
+func (m Millis) IsZero() bool {
+	return m == 0
+}
+
+// millisTime converts an optional timestamp field, the zero time for nil.
+func millisTime(ms *int64) time.Time {
+	if ms == nil {
+		return time.Time{}
+	}
+	return Millis(*ms).Time()
+}
+
+// CompleteAfterTime returns CompleteAfter, the zero time if unset.
+func (t Task) CompleteAfterTime() time.Time {
+	return millisTime(t.CompleteAfter)
+}
+
+// CompleteBeforeTime returns CompleteBefore, the zero time if unset.
+func (t Task) CompleteBeforeTime() time.Time {
+	return millisTime(t.CompleteBefore)
+}
+
+// ETATime returns ETA, the zero time if unset.
+func (t Task) ETATime() time.Time {
+	return millisTime(t.ETA)
+}
+
+// CreatedTime returns TimeCreated.
+func (t Task) CreatedTime() time.Time {
+	return Millis(t.TimeCreated).Time()
+}
+
+// LastModifiedTime returns TimeLastModified.
+func (t Task) LastModifiedTime() time.Time {
+	return Millis(t.TimeLastModified).Time()
+}
+
+// StartAt returns StartTime.
+func (p RoutePlan) StartAt() time.Time {
+	return Millis(p.StartTime).Time()
+}
+
+// EndAt returns EndTime, the zero time if unset.
+func (p RoutePlan) EndAt() time.Time {
+	return millisTime(p.EndTime)
+}
+
+// CreatedTime returns TimeCreated.
+func (p RoutePlan) CreatedTime() time.Time {
+	return Millis(p.TimeCreated).Time()
+}
+
+// LastModifiedTime returns TimeLastModified.
+func (p RoutePlan) LastModifiedTime() time.Time {
+	return Millis(p.TimeLastModified).Time()
+}
+
+// ShiftTimes returns Shifts as start and end times.
+func (s WorkerSchedule) ShiftTimes() [][]time.Time {
+	shifts := make([][]time.Time, len(s.Shifts))
+	for i, shift := range s.Shifts {
+		shifts[i] = make([]time.Time, len(shift))
+		for j, ms := range shift {
+			shifts[i][j] = Millis(ms).Time()
+		}
+	}
+	return shifts
+}
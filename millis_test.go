@@ -0,0 +1,73 @@
+package onfleet
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMillis_Time(t *testing.T) {
+	at := time.Date(2024, 3, 1, 12, 30, 0, int(250*time.Millisecond), time.UTC)
+
+	m := FromTime(at)
+	assert.Equal(t, Millis(1709296200250), m)
+	assert.True(t, m.Time().Equal(at))
+	assert.False(t, m.IsZero())
+
+	assert.Equal(t, Millis(0), FromTime(time.Time{}))
+	assert.True(t, Millis(0).Time().IsZero())
+
+	data, err := json.Marshal(struct {
+		At Millis `json:"at"`
+	}{m})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"at":1709296200250}`, string(data))
+}
+
+func TestModel_TimeAccessors(t *testing.T) {
+	var task Task
+	require.NoError(t, json.Unmarshal([]byte(`{"timeCreated":1709296200250,"timeLastModified":1709296201000,"completeBefore":1709300000000,"eta":null}`), &task))
+	assert.Equal(t, int64(1709296200250), task.CreatedTime().UnixMilli())
+	assert.Equal(t, int64(1709296201000), task.LastModifiedTime().UnixMilli())
+	assert.Equal(t, int64(1709300000000), task.CompleteBeforeTime().UnixMilli())
+	assert.True(t, task.CompleteAfterTime().IsZero())
+	assert.True(t, task.ETATime().IsZero())
+
+	end := int64(1709300000000)
+	plan := RoutePlan{StartTime: 1709296200250, EndTime: &end}
+	assert.Equal(t, int64(1709296200250), plan.StartAt().UnixMilli())
+	assert.Equal(t, end, plan.EndAt().UnixMilli())
+	assert.True(t, plan.CreatedTime().IsZero())
+
+	schedule := WorkerSchedule{Shifts: [][]int64{{1709296200250, 1709300000000}}}
+	shifts := schedule.ShiftTimes()
+	require.Len(t, shifts, 1)
+	assert.Equal(t, int64(1709296200250), shifts[0][0].UnixMilli())
+	assert.Equal(t, int64(1709300000000), shifts[0][1].UnixMilli())
+}
+
+func TestQueryParams_SetRange(t *testing.T) {
+	from := time.UnixMilli(1709296200250)
+	to := from.Add(time.Hour)
+
+	var tasks TaskListQueryParams
+	tasks.SetRange(from, to)
+	assert.Equal(t, TaskListQueryParams{From: 1709296200250, To: 1709299800250}, tasks)
+	tasks.SetRange(from, time.Time{})
+	assert.Equal(t, int64(0), tasks.To, "zero to leaves the range open")
+	tasks.SetCompleteRange(from, to)
+	assert.Equal(t, int64(1709296200250), tasks.CompleteAfterAfter)
+	assert.Equal(t, int64(1709299800250), tasks.CompleteBeforeBefore)
+
+	var plans RoutePlanListQueryParams
+	plans.SetStartTimeRange(from, to)
+	plans.SetCreatedTimeRange(time.Time{}, to)
+	assert.Equal(t, RoutePlanListQueryParams{
+		StartTimeFrom: 1709296200250,
+		StartTimeTo:   1709299800250,
+		CreatedTimeTo: 1709299800250,
+	}, plans)
+}
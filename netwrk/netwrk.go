@@ -20,8 +20,42 @@ import (
 type RlHttpClient struct {
 	Client      *http.Client
 	RateLimiter *rate.Limiter
+	// Middleware wraps every http round trip, outermost first.
+	Middleware []Middleware
+	// RetryPolicy used by Call. nil uses DefaultRetryPolicy.
+	RetryPolicy *RetryPolicy
+
+	// gate pauses all calls while Onfleet reports the rate limit exhausted.
+	gate throttleGate
+}
+
+// Doer sends an http request and returns its response.
+type Doer func(request *http.Request) (*http.Response, error)
+
+// Middleware intercepts an outgoing request and its response.
+// It may modify either, short circuit by not calling next, or
+// replace the returned response / error. It must return a response or an
+// error.
+// Middleware runs once per attempt, so retried calls pass through it again.
+type Middleware func(next Doer) Doer
+
+// do sends request through the middleware chain and then the http client.
+func (rlHttpClient *RlHttpClient) do(request *http.Request) (*http.Response, error) {
+	doer := Doer(rlHttpClient.Client.Do)
+	for i := len(rlHttpClient.Middleware) - 1; i >= 0; i-- {
+		doer = rlHttpClient.Middleware[i](doer)
+	}
+	response, err := doer(request)
+	if err == nil && response == nil {
+		return nil, errNoResponse
+	}
+	return response, err
 }
 
+// errNoResponse is returned when a middleware returns neither a response
+// nor an error.
+var errNoResponse = errors.New("middleware returned no response and no error")
+
 func NewRlHttpClient(rl *rate.Limiter, timeout int64) *RlHttpClient {
 	return &RlHttpClient{
 		Client: &http.Client{
@@ -40,36 +74,33 @@ func urlAttachPath(baseUrl string, pathSegments ...string) string {
 	return newUrl
 }
 
-// stomp converts a struct to a map[string]any
-func stomp(v any) (map[string]any, error) {
-	m := map[string]any{}
-	b, err := json.Marshal(v)
-	if err != nil {
-		return m, err
-	}
-	err = json.Unmarshal(b, &m)
-	return m, err
-}
-
-// urlAttachQuery sets query parameters on the provided baseUrl.
-func urlAttachQuery(baseUrl string, v any) string {
+// urlAttachQuery sets query parameters encoded by EncodeQuery on the
+// provided baseUrl, keeping any query it already has.
+func urlAttachQuery(baseUrl string, v any) (string, error) {
 	URL, err := url.Parse(baseUrl)
 	if err != nil {
-		return baseUrl
+		return baseUrl, err
 	}
-	q := URL.Query()
-	params, err := stomp(v)
+	params, err := EncodeQuery(v)
 	if err != nil {
-		return baseUrl
+		return baseUrl, err
 	}
-	for k, v := range params {
-		q.Set(k, fmt.Sprintf("%v", v))
+	if len(params) == 0 {
+		return baseUrl, nil
+	}
+	q := URL.Query()
+	for k, values := range params {
+		q[k] = values
 	}
 	URL.RawQuery = q.Encode()
-	return URL.String()
+	return URL.String(), nil
 }
 
+// Caller performs a single Onfleet API call. ctx governs the whole call:
+// cancelling it aborts the rate limiter wait, any retry backoff and the
+// in-flight http request.
 type Caller func(
+	ctx context.Context,
 	apiKey string,
 	rlHttpClient *RlHttpClient,
 	method string,
@@ -82,6 +113,7 @@ type Caller func(
 ) error
 
 func Call(
+	ctx context.Context,
 	apiKey string,
 	rlHttpClient *RlHttpClient,
 	method string,
@@ -92,23 +124,35 @@ func Call(
 	v any,
 	additionalHeaders ...[2]string,
 ) error {
-	exponentialBackOff := backoff.NewExponentialBackOff()
-	exponentialBackOff.MaxElapsedTime = 15 * time.Second
-	ctx := context.Background()
-	b := backoff.WithContext(exponentialBackOff, ctx)
+	policy := DefaultRetryPolicy()
+	if rlHttpClient.RetryPolicy != nil {
+		policy = *rlHttpClient.RetryPolicy
+	}
+	b := &throttledBackOff{BackOff: policy.backOff()}
 	return backoff.Retry(func() error {
-		err := callInternal(ctx, apiKey, rlHttpClient, method, baseUrl, pathSegments, queryParams, body, v, additionalHeaders)
-		if errors.Is(err, onfleet.TooManyRequestsError{}) {
-			return err
+		statusCode, err := callAttempt(ctx, apiKey, rlHttpClient, method, baseUrl, pathSegments, queryParams, body, v, additionalHeaders)
+		if err == nil {
+			return nil
 		}
-		if err != nil {
-			return backoff.Permanent(err)
+		if policy.shouldRetry(ctx, method, statusCode, err) {
+			var tooManyRequestsErr onfleet.TooManyRequestsError
+			if errors.As(err, &tooManyRequestsErr) {
+				b.wait(tooManyRequestsErr.RetryAfter)
+			}
+			return err
 		}
-		return nil
-	}, b)
+		return backoff.Permanent(err)
+	}, backoff.WithContext(b, ctx))
 }
 
 func callInternal(ctx context.Context, apiKey string, rlHttpClient *RlHttpClient, method string, baseUrl string, pathSegments []string, queryParams any, body any, v any, additionalHeaders [][2]string) error {
+	_, err := callAttempt(ctx, apiKey, rlHttpClient, method, baseUrl, pathSegments, queryParams, body, v, additionalHeaders)
+	return err
+}
+
+// callAttempt makes a single request. The response status code is returned
+// alongside the error, 0 if no response was received.
+func callAttempt(ctx context.Context, apiKey string, rlHttpClient *RlHttpClient, method string, baseUrl string, pathSegments []string, queryParams any, body any, v any, additionalHeaders [][2]string) (int, error) {
 	var request *http.Request
 	var err error
 
@@ -117,37 +161,42 @@ func callInternal(ctx context.Context, apiKey string, rlHttpClient *RlHttpClient
 		callUrl = urlAttachPath(callUrl, pathSegments...)
 	}
 	if queryParams != nil {
-		callUrl = urlAttachQuery(callUrl, queryParams)
+		callUrl, err = urlAttachQuery(callUrl, queryParams)
+		if err != nil {
+			return 0, err
+		}
 	}
 
-	switch method {
-	case "GET", "DELETE":
-		request, err = http.NewRequest(
+	switch {
+	case method == "GET" || method == "DELETE" && body == nil:
+		request, err = http.NewRequestWithContext(
+			ctx,
 			method,
 			callUrl,
 			nil,
 		)
 		if err != nil {
-			return err
+			return 0, err
 		}
 		request.Header.Set("Accept", "application/json")
-	case "POST", "PUT":
+	case method == "POST" || method == "PUT" || method == "DELETE":
 		bodyMarshal, errMarshal := json.Marshal(body)
 		if errMarshal != nil {
-			return errMarshal
+			return 0, errMarshal
 		}
 		buffer := bytes.NewBuffer(bodyMarshal)
-		request, err = http.NewRequest(
+		request, err = http.NewRequestWithContext(
+			ctx,
 			method,
 			callUrl,
 			buffer,
 		)
 		if err != nil {
-			return err
+			return 0, err
 		}
 		request.Header.Set("Content-Type", "application/json")
 	default:
-		return fmt.Errorf("unsupported method: %s", method)
+		return 0, fmt.Errorf("unsupported method: %s", method)
 	}
 
 	for _, h := range additionalHeaders {
@@ -158,26 +207,36 @@ func callInternal(ctx context.Context, apiKey string, rlHttpClient *RlHttpClient
 	request.Header.Set("User-Agent", fmt.Sprintf("%s-%s", version.Name, version.Value))
 	request.SetBasicAuth(apiKey, "")
 
+	err = rlHttpClient.gate.wait(ctx)
+	if err != nil {
+		return 0, err
+	}
 	err = rlHttpClient.RateLimiter.Wait(ctx)
 	if err != nil {
-		return err
+		return 0, err
 	}
-	response, err := rlHttpClient.Client.Do(request)
+	response, err := rlHttpClient.do(request)
 	if err != nil {
-		return err
+		return 0, err
 	}
 	defer response.Body.Close()
-	if response.StatusCode == http.StatusTooManyRequests || response.StatusCode == http.StatusPreconditionFailed {
-		return onfleet.TooManyRequestsError{}
+	now := time.Now()
+	rlHttpClient.throttle(response, now)
+	if response.StatusCode == http.StatusTooManyRequests {
+		return response.StatusCode, newTooManyRequestsError(response, now)
 	}
 	if response.StatusCode < 200 || response.StatusCode > 299 {
-		return onfleet.ParseError(response.Body)
+		err = onfleet.ParseResponseError(response)
+		if isThrottled(response.StatusCode, err) {
+			return response.StatusCode, newTooManyRequestsError(response, now)
+		}
+		return response.StatusCode, err
 	}
 	if v == nil {
-		return nil
+		return response.StatusCode, nil
 	}
 	if err = json.NewDecoder(response.Body).Decode(v); err != nil {
-		return err
+		return response.StatusCode, err
 	}
-	return nil
+	return response.StatusCode, nil
 }
@@ -3,7 +3,9 @@ package netwrk
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -102,75 +104,6 @@ func TestUrlAttachPath(t *testing.T) {
 	}
 }
 
-func TestStomp(t *testing.T) {
-	tests := []struct {
-		name     string
-		input    interface{}
-		expected map[string]any
-		hasError bool
-	}{
-		{
-			name: "simple struct",
-			input: struct {
-				Name string `json:"name"`
-				Age  int    `json:"age"`
-			}{
-				Name: "John",
-				Age:  30,
-			},
-			expected: map[string]any{
-				"name": "John",
-				"age":  float64(30), // JSON unmarshals numbers as float64
-			},
-			hasError: false,
-		},
-		{
-			name:     "nil input",
-			input:    nil,
-			expected: map[string]any{},
-			hasError: false,
-		},
-		{
-			name: "nested struct",
-			input: struct {
-				User struct {
-					Name string `json:"name"`
-				} `json:"user"`
-				Count int `json:"count"`
-			}{
-				User: struct {
-					Name string `json:"name"`
-				}{Name: "Alice"},
-				Count: 5,
-			},
-			expected: map[string]any{
-				"user": map[string]any{
-					"name": "Alice",
-				},
-				"count": float64(5),
-			},
-			hasError: false,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result, err := stomp(tt.input)
-
-			if tt.hasError && err == nil {
-				t.Error("Expected error but got none")
-			}
-			if !tt.hasError && err != nil {
-				t.Errorf("Unexpected error: %v", err)
-			}
-
-			if !equalMaps(result, tt.expected) {
-				t.Errorf("Expected %+v, got %+v", tt.expected, result)
-			}
-		})
-	}
-}
-
 func TestUrlAttachQuery(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -216,7 +149,10 @@ func TestUrlAttachQuery(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := urlAttachQuery(tt.baseUrl, tt.params)
+			result, err := urlAttachQuery(tt.baseUrl, tt.params)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
 			
 			// For URL query parameters, order might vary, so we need to check components
 			if tt.params == nil || (fmt.Sprintf("%T", tt.params) == "struct {}" && 
@@ -383,6 +319,93 @@ func TestCallInternal_POST(t *testing.T) {
 	}
 }
 
+func TestCallInternal_DELETEWithBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" {
+			t.Errorf("Expected DELETE, got %s", r.Method)
+		}
+
+		if r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("Expected Content-Type 'application/json', got '%s'", r.Header.Get("Content-Type"))
+		}
+
+		var requestBody map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
+			t.Errorf("Error decoding request body: %v", err)
+		}
+
+		if requestBody["key"] != "boxes" {
+			t.Errorf("Expected key 'boxes', got '%v'", requestBody["key"])
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	rl := rate.NewLimiter(rate.Every(1*time.Second), 10)
+	rlHttpClient := NewRlHttpClient(rl, 5000)
+
+	err := callInternal(
+		context.Background(),
+		"test_api_key",
+		rlHttpClient,
+		"DELETE",
+		server.URL+"/test",
+		nil,
+		nil,
+		map[string]any{"key": "boxes"},
+		nil,
+		[][2]string{},
+	)
+
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+func TestCallInternal_DELETEWithoutBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" {
+			t.Errorf("Expected DELETE, got %s", r.Method)
+		}
+
+		if r.Header.Get("Content-Type") != "" {
+			t.Errorf("Expected no Content-Type, got '%s'", r.Header.Get("Content-Type"))
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("Error reading request body: %v", err)
+		}
+		if len(body) != 0 {
+			t.Errorf("Expected empty body, got '%s'", body)
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	rl := rate.NewLimiter(rate.Every(1*time.Second), 10)
+	rlHttpClient := NewRlHttpClient(rl, 5000)
+
+	err := callInternal(
+		context.Background(),
+		"test_api_key",
+		rlHttpClient,
+		"DELETE",
+		server.URL+"/test",
+		nil,
+		nil,
+		nil,
+		nil,
+		[][2]string{},
+	)
+
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
 func TestCallInternal_ErrorResponses(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -415,7 +438,14 @@ func TestCallInternal_ErrorResponses(t *testing.T) {
 		{
 			name:            "412 Precondition Failed",
 			statusCode:      http.StatusPreconditionFailed,
-			responseBody:    map[string]any{"error": "Precondition failed"},
+			responseBody:    map[string]any{"code": "PreconditionFailed", "message": map[string]any{"message": "Precondition failed"}},
+			expectedError:   true,
+			expectedTooMany: false,
+		},
+		{
+			name:            "412 Too Many Requests",
+			statusCode:      http.StatusPreconditionFailed,
+			responseBody:    map[string]any{"code": "TooManyRequests", "message": map[string]any{"message": "Rate limited"}},
 			expectedError:   true,
 			expectedTooMany: true,
 		},
@@ -568,6 +598,7 @@ func TestCall_WithRetry(t *testing.T) {
 
 	var result map[string]any
 	err := Call(
+		context.Background(),
 		"test_api_key",
 		rlHttpClient,
 		"GET",
@@ -607,6 +638,7 @@ func TestCall_PermanentError(t *testing.T) {
 	rlHttpClient := NewRlHttpClient(rl, 5000)
 
 	err := Call(
+		context.Background(),
 		"test_api_key",
 		rlHttpClient,
 		"GET",
@@ -627,32 +659,236 @@ func TestCall_PermanentError(t *testing.T) {
 	}
 }
 
-// Helper function to compare maps
-func equalMaps(a, b map[string]any) bool {
-	if len(a) != len(b) {
-		return false
+func TestCall_ContextCanceledDuringBackoff(t *testing.T) {
+	requestCount := 0
+
+	// Always throttle so Call keeps backing off until ctx is done
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount++
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	rl := rate.NewLimiter(rate.Every(1*time.Millisecond), 100)
+	rlHttpClient := NewRlHttpClient(rl, 5000)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := Call(
+		ctx,
+		"test_api_key",
+		rlHttpClient,
+		"GET",
+		server.URL+"/test",
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	if err == nil {
+		t.Fatal("Expected error when context deadline is exceeded")
 	}
-	
-	for k, v := range a {
-		if bv, exists := b[k]; !exists {
-			return false
-		} else if !equalValues(v, bv) {
-			return false
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Expected Call to stop shortly after deadline, took %v", elapsed)
+	}
+	if requestCount == 0 {
+		t.Error("Expected at least one request before the deadline")
+	}
+}
+
+func TestCall_ContextCanceledDuringRateLimitWait(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("{}"))
+	}))
+	defer server.Close()
+
+	// Empty bucket with a slow refill forces Wait to block
+	rl := rate.NewLimiter(rate.Every(1*time.Hour), 1)
+	rl.Allow()
+	rlHttpClient := NewRlHttpClient(rl, 5000)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err := Call(
+		ctx,
+		"test_api_key",
+		rlHttpClient,
+		"GET",
+		server.URL+"/test",
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	if err == nil {
+		t.Error("Expected error when rate limiter wait exceeds context deadline")
+	}
+}
+
+func TestCallInternal_ContextCanceledInFlight(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-release:
 		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	rl := rate.NewLimiter(rate.Every(1*time.Millisecond), 100)
+	rlHttpClient := NewRlHttpClient(rl, 5000)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err := callInternal(
+		ctx,
+		"test_api_key",
+		rlHttpClient,
+		"GET",
+		server.URL+"/test",
+		nil,
+		nil,
+		nil,
+		nil,
+		[][2]string{},
+	)
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
 	}
-	
-	return true
 }
 
-// Helper function to compare interface{} values
-func equalValues(a, b any) bool {
-	// Handle nested maps
-	if aMap, aOk := a.(map[string]any); aOk {
-		if bMap, bOk := b.(map[string]any); bOk {
-			return equalMaps(aMap, bMap)
+func TestCallInternal_Middleware(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Correlation-Id") != "abc" {
+			t.Errorf("Expected X-Correlation-Id 'abc', got '%s'", r.Header.Get("X-Correlation-Id"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id":"test_123"}`))
+	}))
+	defer server.Close()
+
+	var order []string
+	tracing := func(name string) Middleware {
+		return func(next Doer) Doer {
+			return func(request *http.Request) (*http.Response, error) {
+				order = append(order, name+" request")
+				response, err := next(request)
+				order = append(order, name+" response")
+				return response, err
+			}
 		}
-		return false
 	}
-	
-	return a == b
-}
\ No newline at end of file
+	correlation := func(next Doer) Doer {
+		return func(request *http.Request) (*http.Response, error) {
+			request.Header.Set("X-Correlation-Id", "abc")
+			return next(request)
+		}
+	}
+
+	rl := rate.NewLimiter(rate.Every(1*time.Second), 10)
+	rlHttpClient := NewRlHttpClient(rl, 5000)
+	rlHttpClient.Middleware = []Middleware{tracing("outer"), correlation, tracing("inner")}
+
+	var result map[string]any
+	err := callInternal(
+		context.Background(),
+		"test_api_key",
+		rlHttpClient,
+		"GET",
+		server.URL+"/test",
+		nil,
+		nil,
+		nil,
+		&result,
+		[][2]string{},
+	)
+
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if result["id"] != "test_123" {
+		t.Errorf("Expected id 'test_123', got '%v'", result["id"])
+	}
+	expectedOrder := []string{"outer request", "inner request", "inner response", "outer response"}
+	if fmt.Sprint(order) != fmt.Sprint(expectedOrder) {
+		t.Errorf("Expected order %v, got %v", expectedOrder, order)
+	}
+}
+
+func TestCallInternal_MiddlewareShortCircuit(t *testing.T) {
+	requestCount := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount++
+	}))
+	defer server.Close()
+
+	injected := errors.New("injected fault")
+	rl := rate.NewLimiter(rate.Every(1*time.Second), 10)
+	rlHttpClient := NewRlHttpClient(rl, 5000)
+	rlHttpClient.Middleware = []Middleware{
+		func(next Doer) Doer {
+			return func(request *http.Request) (*http.Response, error) {
+				return nil, injected
+			}
+		},
+	}
+
+	err := callInternal(
+		context.Background(),
+		"test_api_key",
+		rlHttpClient,
+		"GET",
+		server.URL+"/test",
+		nil,
+		nil,
+		nil,
+		nil,
+		[][2]string{},
+	)
+
+	if !errors.Is(err, injected) {
+		t.Errorf("Expected injected error, got %v", err)
+	}
+	if requestCount != 0 {
+		t.Errorf("Expected no request to reach the server, got %d", requestCount)
+	}
+}
+
+func TestCallInternal_MiddlewareNoResponse(t *testing.T) {
+	rl := rate.NewLimiter(rate.Every(1*time.Second), 10)
+	rlHttpClient := NewRlHttpClient(rl, 5000)
+	rlHttpClient.Middleware = []Middleware{
+		func(next Doer) Doer {
+			return func(request *http.Request) (*http.Response, error) {
+				return nil, nil
+			}
+		},
+	}
+
+	err := callInternal(
+		context.Background(),
+		"test_api_key",
+		rlHttpClient,
+		"GET",
+		"http://example.invalid/test",
+		nil,
+		nil,
+		nil,
+		nil,
+		[][2]string{},
+	)
+
+	if !errors.Is(err, errNoResponse) {
+		t.Errorf("Expected errNoResponse, got %v", err)
+	}
+}
@@ -0,0 +1,252 @@
+package netwrk
+
+import (
+	"encoding"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EncodeQuery encodes v, a struct, pointer to struct or map with string
+// keys, into query values.
+//
+// Fields are named by their `query` tag, falling back to their `json` tag,
+// and support the omitempty and "-" options of encoding/json. The json
+// ",string" option is ignored as every value is sent as a string.
+//
+//   - integers and floats are written in full, never in exponent notation
+//   - booleans are written as true / false
+//   - time.Time is written as unix milliseconds
+//   - encoding.TextMarshaler values are written as their text
+//   - slices are comma joined, e.g. ids=a,b. The `query:"ids,repeat"` option
+//     repeats the key instead, ids=a&ids=b
+//   - nested structs and maps are written as parent[child]=value
+//   - nil pointers are omitted, others are dereferenced
+func EncodeQuery(v any) (url.Values, error) {
+	values := url.Values{}
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return values, nil
+		}
+		rv = rv.Elem()
+	}
+	switch rv.Kind() {
+	case reflect.Invalid:
+		return values, nil
+	case reflect.Struct, reflect.Map:
+		err := encodeQueryValue(values, "", rv, queryField{})
+		return values, err
+	}
+	return nil, fmt.Errorf("query params must be a struct or map, got %s", rv.Type())
+}
+
+type queryField struct {
+	name      string
+	omitEmpty bool
+	repeat    bool
+}
+
+// parseQueryField reads the name and options of a struct field.
+// ok is false for fields which are not encoded.
+func parseQueryField(field reflect.StructField) (f queryField, ok bool) {
+	tag, hasTag := field.Tag.Lookup("query")
+	if !hasTag {
+		tag, hasTag = field.Tag.Lookup("json")
+	}
+	if tag == "-" {
+		return f, false
+	}
+	name, options, _ := strings.Cut(tag, ",")
+	f.name = name
+	if !hasTag || f.name == "" {
+		f.name = field.Name
+	}
+	for _, option := range strings.Split(options, ",") {
+		switch option {
+		case "omitempty":
+			f.omitEmpty = true
+		case "repeat":
+			f.repeat = true
+		}
+	}
+	return f, true
+}
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+func queryKey(prefix string, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "[" + name + "]"
+}
+
+func encodeQueryValue(values url.Values, key string, rv reflect.Value, f queryField) error {
+	if f.omitEmpty && isEmptyQueryValue(rv) {
+		return nil
+	}
+	for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+
+	if s, ok, err := formatQueryScalar(rv); ok || err != nil {
+		if err != nil {
+			return fmt.Errorf("query param %s: %w", key, err)
+		}
+		values.Set(key, s)
+		return nil
+	}
+
+	switch rv.Kind() {
+	case reflect.Struct:
+		return encodeQueryStruct(values, key, rv)
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return fmt.Errorf("query param %s: map keys must be strings, got %s", key, rv.Type().Key())
+		}
+		iter := rv.MapRange()
+		for iter.Next() {
+			if err := encodeQueryValue(values, queryKey(key, iter.Key().String()), iter.Value(), queryField{}); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Slice, reflect.Array:
+		items := make([]string, 0, rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			item := rv.Index(i)
+			for item.Kind() == reflect.Pointer || item.Kind() == reflect.Interface {
+				if item.IsNil() {
+					item = reflect.Value{}
+					break
+				}
+				item = item.Elem()
+			}
+			s, ok, err := formatQueryScalar(item)
+			if err != nil {
+				return fmt.Errorf("query param %s: %w", key, err)
+			}
+			if !ok {
+				return fmt.Errorf("query param %s: unsupported slice element %s", key, item.Type())
+			}
+			items = append(items, s)
+		}
+		values.Del(key)
+		if f.repeat {
+			for _, item := range items {
+				values.Add(key, item)
+			}
+		} else {
+			values.Set(key, strings.Join(items, ","))
+		}
+		return nil
+	}
+	return fmt.Errorf("query param %s: unsupported type %s", key, rv.Type())
+}
+
+func encodeQueryStruct(values url.Values, prefix string, rv reflect.Value) error {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		f, ok := parseQueryField(field)
+		if !ok {
+			continue
+		}
+		fv := rv.Field(i)
+		if field.Anonymous && !hasQueryName(field) {
+			// flatten embedded structs as encoding/json does
+			for fv.Kind() == reflect.Pointer {
+				if fv.IsNil() {
+					break
+				}
+				fv = fv.Elem()
+			}
+			if fv.Kind() == reflect.Struct {
+				if err := encodeQueryStruct(values, prefix, fv); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if err := encodeQueryValue(values, queryKey(prefix, f.name), fv, f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func hasQueryName(field reflect.StructField) bool {
+	for _, key := range []string{"query", "json"} {
+		if tag, ok := field.Tag.Lookup(key); ok {
+			name, _, _ := strings.Cut(tag, ",")
+			return name != ""
+		}
+	}
+	return false
+}
+
+// formatQueryScalar formats rv if it is a single value.
+func formatQueryScalar(rv reflect.Value) (string, bool, error) {
+	if !rv.IsValid() {
+		return "", true, nil
+	}
+	if rv.Type() == timeType {
+		return strconv.FormatInt(rv.Interface().(time.Time).UnixMilli(), 10), true, nil
+	}
+	if rv.Type().Implements(textMarshalerType) {
+		text, err := rv.Interface().(encoding.TextMarshaler).MarshalText()
+		return string(text), true, err
+	}
+	switch rv.Kind() {
+	case reflect.String:
+		return rv.String(), true, nil
+	case reflect.Bool:
+		return strconv.FormatBool(rv.Bool()), true, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), true, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(rv.Uint(), 10), true, nil
+	case reflect.Float32:
+		return strconv.FormatFloat(rv.Float(), 'f', -1, 32), true, nil
+	case reflect.Float64:
+		return strconv.FormatFloat(rv.Float(), 'f', -1, 64), true, nil
+	}
+	return "", false, nil
+}
+
+// isEmptyQueryValue mirrors encoding/json's omitempty, also treating the
+// zero time.Time as empty.
+func isEmptyQueryValue(rv reflect.Value) bool {
+	switch rv.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return rv.Len() == 0
+	case reflect.Pointer, reflect.Interface:
+		return rv.IsNil()
+	case reflect.Bool:
+		return !rv.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return rv.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return rv.Float() == 0
+	case reflect.Struct:
+		if rv.Type() == timeType {
+			return rv.Interface().(time.Time).IsZero()
+		}
+	}
+	return false
+}
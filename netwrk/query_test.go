@@ -0,0 +1,276 @@
+package netwrk
+
+import (
+	"math"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	onfleet "github.com/onfleet/gonfleet"
+)
+
+type queryInner struct {
+	Lat float64 `json:"lat"`
+	Lng float64 `json:"lng,omitempty"`
+}
+
+type QueryEmbedded struct {
+	Page int `json:"page,omitempty"`
+}
+
+type queryColor int
+
+func (c queryColor) MarshalText() ([]byte, error) {
+	return []byte("color-" + strconv.Itoa(int(c))), nil
+}
+
+func TestEncodeQuery(t *testing.T) {
+	when := time.UnixMilli(1700000000123)
+	tests := []struct {
+		name     string
+		params   any
+		expected url.Values
+		hasError bool
+	}{
+		{
+			name:     "nil",
+			params:   nil,
+			expected: url.Values{},
+		},
+		{
+			name:     "nil pointer",
+			params:   (*onfleet.TaskListQueryParams)(nil),
+			expected: url.Values{},
+		},
+		{
+			name: "task list params",
+			params: onfleet.TaskListQueryParams{
+				From:         1455072025000,
+				To:           1700000000000,
+				Dependencies: []string{"a", "b"},
+			},
+			expected: url.Values{
+				"from":         {"1455072025000"},
+				"to":           {"1700000000000"},
+				"dependencies": {"a,b"},
+			},
+		},
+		{
+			name:     "pointer to struct",
+			params:   &onfleet.TaskListQueryParams{Worker: "w1"},
+			expected: url.Values{"worker": {"w1"}},
+		},
+		{
+			name: "floats are not in exponent notation",
+			params: struct {
+				Small float64 `json:"small"`
+				Large float64 `json:"large"`
+				F32   float32 `json:"f32"`
+			}{Small: 0.0000001, Large: 1e21, F32: 0.1},
+			expected: url.Values{
+				"small": {"0.0000001"},
+				"large": {"1000000000000000000000"},
+				"f32":   {"0.1"},
+			},
+		},
+		{
+			name: "int64 keeps precision",
+			params: struct {
+				Max int64  `json:"max"`
+				U   uint64 `json:"u"`
+			}{Max: math.MaxInt64, U: math.MaxUint64},
+			expected: url.Values{
+				"max": {"9223372036854775807"},
+				"u":   {"18446744073709551615"},
+			},
+		},
+		{
+			name: "booleans",
+			params: struct {
+				HasTasks bool  `json:"hasTasks"`
+				Omitted  bool  `json:"omitted,omitempty"`
+				Ptr      *bool `json:"ptr,omitempty"`
+			}{HasTasks: true, Ptr: onfleet.Ptr(false)},
+			expected: url.Values{
+				"hasTasks": {"true"},
+				"ptr":      {"false"},
+			},
+		},
+		{
+			name: "time",
+			params: struct {
+				At   time.Time  `json:"at"`
+				Zero time.Time  `json:"zero,omitempty"`
+				Ptr  *time.Time `json:"ptr,omitempty"`
+			}{At: when, Ptr: &when},
+			expected: url.Values{
+				"at":  {"1700000000123"},
+				"ptr": {"1700000000123"},
+			},
+		},
+		{
+			name: "repeated slice",
+			params: struct {
+				Ids   []string `query:"ids,repeat" json:"-"`
+				Empty []string `query:"empty,omitempty,repeat"`
+				Ints  []int64  `json:"ints"`
+			}{Ids: []string{"a", "b"}, Ints: []int64{1, 2}},
+			expected: url.Values{
+				"ids":  {"a", "b"},
+				"ints": {"1,2"},
+			},
+		},
+		{
+			name: "nested struct and map",
+			params: struct {
+				Location queryInner        `json:"location"`
+				Skipped  *queryInner       `json:"skipped,omitempty"`
+				Meta     map[string]string `json:"meta"`
+			}{
+				Location: queryInner{Lat: 1.5},
+				Meta:     map[string]string{"k": "v"},
+			},
+			expected: url.Values{
+				"location[lat]": {"1.5"},
+				"meta[k]":       {"v"},
+			},
+		},
+		{
+			name: "embedded struct, text marshaler and skipped fields",
+			params: struct {
+				QueryEmbedded
+				Color    queryColor `json:"color"`
+				Skip     string     `json:"-"`
+				NoTag    string
+				internal string
+			}{
+				QueryEmbedded: QueryEmbedded{Page: 2},
+				Color:         3,
+				Skip:          "x",
+				NoTag:         "y",
+				internal:      "z",
+			},
+			expected: url.Values{
+				"page":  {"2"},
+				"color": {"color-3"},
+				"NoTag": {"y"},
+			},
+		},
+		{
+			name:     "map",
+			params:   map[string]any{"limit": 10, "name": "John"},
+			expected: url.Values{"limit": {"10"}, "name": {"John"}},
+		},
+		{
+			name:     "not a struct",
+			params:   "limit=10",
+			hasError: true,
+		},
+		{
+			name: "unsupported slice element",
+			params: struct {
+				Items []queryInner `json:"items"`
+			}{Items: []queryInner{{}}},
+			hasError: true,
+		},
+		{
+			name: "unsupported map key",
+			params: struct {
+				M map[int]string `json:"m"`
+			}{M: map[int]string{1: "a"}},
+			hasError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, err := EncodeQuery(tt.params)
+			if tt.hasError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, values)
+		})
+	}
+}
+
+func TestUrlAttachQuery_Encoding(t *testing.T) {
+	result, err := urlAttachQuery("https://api.example.com/tasks?from=1", onfleet.TaskListQueryParams{
+		From:         1700000000000,
+		Dependencies: []string{"a", "b"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "https://api.example.com/tasks?dependencies=a%2Cb&from=1700000000000", result)
+
+	_, err = urlAttachQuery("https://api.example.com/tasks", 42)
+	assert.Error(t, err)
+}
+
+func FuzzEncodeQuery(f *testing.F) {
+	f.Add("name", int64(1455072025000), 1e21, true, "a,b")
+	f.Add("", int64(math.MinInt64), 0.0000001, false, "")
+	f.Add("&=?[]", int64(-1), math.Inf(1), true, "ü,\x00")
+	f.Fuzz(func(t *testing.T, s string, i int64, fl float64, b bool, item string) {
+		params := struct {
+			S      string   `json:"s"`
+			I      int64    `json:"i,string"`
+			F      float64  `json:"f"`
+			B      bool     `json:"b"`
+			Items  []string `query:"items,repeat"`
+			Joined []string `json:"joined,omitempty"`
+		}{S: s, I: i, F: fl, B: b, Items: []string{item, s}, Joined: []string{item}}
+
+		values, err := EncodeQuery(params)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// the encoded query must survive a round trip through a url
+		result, err := urlAttachQuery("https://api.example.com/tasks?keep=1", params)
+		if err != nil {
+			t.Fatal(err)
+		}
+		parsed, err := url.Parse(result)
+		if err != nil {
+			t.Fatal(err)
+		}
+		q := parsed.Query()
+		if q.Get("keep") != "1" {
+			t.Fatalf("existing query lost in %s", result)
+		}
+		for k := range values {
+			if strings.Join(q[k], "\x00") != strings.Join(values[k], "\x00") {
+				t.Fatalf("%s: got %q, want %q", k, q[k], values[k])
+			}
+		}
+
+		if q.Get("s") != s {
+			t.Fatalf("s: got %q, want %q", q.Get("s"), s)
+		}
+		gotInt, err := strconv.ParseInt(q.Get("i"), 10, 64)
+		if err != nil || gotInt != i {
+			t.Fatalf("i: got %q, want %d", q.Get("i"), i)
+		}
+		f := q.Get("f")
+		if strings.ContainsAny(f, "eE") && !math.IsInf(fl, 0) {
+			t.Fatalf("f: exponent notation %q", f)
+		}
+		gotFloat, err := strconv.ParseFloat(f, 64)
+		if err != nil || (gotFloat != fl && !(math.IsNaN(fl) && math.IsNaN(gotFloat))) {
+			t.Fatalf("f: got %q, want %v", f, fl)
+		}
+		if q.Get("b") != strconv.FormatBool(b) {
+			t.Fatalf("b: got %q, want %v", q.Get("b"), b)
+		}
+		if len(q["items"]) != 2 || q["items"][0] != item || q["items"][1] != s {
+			t.Fatalf("items: got %q", q["items"])
+		}
+		if q.Get("joined") != item {
+			t.Fatalf("joined: got %q, want %q", q.Get("joined"), item)
+		}
+	})
+}
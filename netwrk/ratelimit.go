@@ -0,0 +1,147 @@
+package netwrk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+
+	onfleet "github.com/onfleet/gonfleet"
+)
+
+// throttleGate holds back every call made through an RlHttpClient after
+// Onfleet signals the rate limit has been hit.
+type throttleGate struct {
+	mu    sync.Mutex
+	until time.Time
+}
+
+// pause delays calls until the given time. Earlier times are ignored.
+func (g *throttleGate) pause(until time.Time) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if until.After(g.until) {
+		g.until = until
+	}
+}
+
+// wait blocks until the gate opens or ctx is done.
+func (g *throttleGate) wait(ctx context.Context) error {
+	g.mu.Lock()
+	d := time.Until(g.until)
+	g.mu.Unlock()
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// throttle pauses the client according to the rate limit headers of response.
+func (rlHttpClient *RlHttpClient) throttle(response *http.Response, now time.Time) {
+	if retryAfter := parseRetryAfter(response.Header.Get("Retry-After"), now); retryAfter > 0 {
+		rlHttpClient.gate.pause(now.Add(retryAfter))
+		return
+	}
+	remaining, ok := parseHeaderInt(response.Header.Get("X-RateLimit-Remaining"))
+	if !ok || remaining > 0 {
+		return
+	}
+	if reset := parseRateLimitReset(response.Header.Get("X-RateLimit-Reset"), now); !reset.IsZero() {
+		rlHttpClient.gate.pause(reset)
+	}
+}
+
+// isThrottled reports whether a 412 response is Onfleet's rate limiting
+// rather than a failed precondition, told apart by its error code.
+func isThrottled(statusCode int, err error) bool {
+	var reqError onfleet.RequestError
+	return statusCode == http.StatusPreconditionFailed &&
+		errors.As(err, &reqError) &&
+		reqError.Code == onfleet.ErrorCodeTooManyRequests
+}
+
+// newTooManyRequestsError builds the error for a throttled response.
+func newTooManyRequestsError(response *http.Response, now time.Time) onfleet.TooManyRequestsError {
+	limit, _ := parseHeaderInt(response.Header.Get("X-RateLimit-Limit"))
+	remaining, _ := parseHeaderInt(response.Header.Get("X-RateLimit-Remaining"))
+	return onfleet.TooManyRequestsError{
+		StatusCode:         response.StatusCode,
+		RetryAfter:         parseRetryAfter(response.Header.Get("Retry-After"), now),
+		RateLimitLimit:     limit,
+		RateLimitRemaining: remaining,
+		RateLimitReset:     parseRateLimitReset(response.Header.Get("X-RateLimit-Reset"), now),
+	}
+}
+
+func parseHeaderInt(value string) (int, bool) {
+	i, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, false
+	}
+	return i, true
+}
+
+// parseRetryAfter accepts either delay seconds or an http date.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if seconds <= 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil && date.After(now) {
+		return date.Sub(now)
+	}
+	return 0
+}
+
+// parseRateLimitReset accepts epoch seconds, epoch milliseconds or,
+// for small values, seconds until reset.
+func parseRateLimitReset(value string, now time.Time) time.Time {
+	i, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || i <= 0 {
+		return time.Time{}
+	}
+	switch {
+	case i >= 1e12:
+		return time.UnixMilli(i)
+	case i >= 1e9:
+		return time.Unix(i, 0)
+	default:
+		return now.Add(time.Duration(i) * time.Second)
+	}
+}
+
+// throttledBackOff waits at least as long as the server asked for.
+type throttledBackOff struct {
+	backoff.BackOff
+	hint time.Duration
+}
+
+// wait sets a minimum for the next backoff interval.
+func (b *throttledBackOff) wait(d time.Duration) {
+	b.hint = d
+}
+
+func (b *throttledBackOff) NextBackOff() time.Duration {
+	next := b.BackOff.NextBackOff()
+	if next != backoff.Stop && b.hint > next {
+		next = b.hint
+	}
+	b.hint = 0
+	return next
+}
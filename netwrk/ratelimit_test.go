@@ -0,0 +1,228 @@
+package netwrk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	onfleet "github.com/onfleet/gonfleet"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		value    string
+		expected time.Duration
+	}{
+		{name: "empty", value: "", expected: 0},
+		{name: "seconds", value: "3", expected: 3 * time.Second},
+		{name: "negative", value: "-1", expected: 0},
+		{name: "http date", value: now.Add(10 * time.Second).Format(http.TimeFormat), expected: 10 * time.Second},
+		{name: "http date in past", value: now.Add(-10 * time.Second).Format(http.TimeFormat), expected: 0},
+		{name: "garbage", value: "soon", expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := parseRetryAfter(tt.value, now)
+			if result != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestParseRateLimitReset(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		value    string
+		expected time.Time
+	}{
+		{name: "empty", value: "", expected: time.Time{}},
+		{name: "epoch seconds", value: "1704110410", expected: time.Unix(1704110410, 0)},
+		{name: "epoch milliseconds", value: "1704110410000", expected: time.UnixMilli(1704110410000)},
+		{name: "delta seconds", value: "5", expected: now.Add(5 * time.Second)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := parseRateLimitReset(tt.value, now)
+			if !result.Equal(tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestCallInternal_TooManyRequestsHeaders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "2")
+		w.Header().Set("X-RateLimit-Limit", "20")
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", "1704110410")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	rl := rate.NewLimiter(rate.Every(1*time.Millisecond), 100)
+	rlHttpClient := NewRlHttpClient(rl, 5000)
+
+	err := callInternal(
+		context.Background(),
+		"test_api_key",
+		rlHttpClient,
+		"GET",
+		server.URL+"/test",
+		nil,
+		nil,
+		nil,
+		nil,
+		[][2]string{},
+	)
+
+	var tooManyErr onfleet.TooManyRequestsError
+	if !errors.As(err, &tooManyErr) {
+		t.Fatalf("Expected TooManyRequestsError, got %v", err)
+	}
+	if !errors.Is(err, onfleet.TooManyRequestsError{}) {
+		t.Error("Expected errors.Is to match an empty TooManyRequestsError")
+	}
+	if tooManyErr.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("Expected status 429, got %d", tooManyErr.StatusCode)
+	}
+	if tooManyErr.RetryAfter != 2*time.Second {
+		t.Errorf("Expected RetryAfter 2s, got %v", tooManyErr.RetryAfter)
+	}
+	if tooManyErr.RateLimitLimit != 20 {
+		t.Errorf("Expected RateLimitLimit 20, got %d", tooManyErr.RateLimitLimit)
+	}
+	if tooManyErr.RateLimitRemaining != 0 {
+		t.Errorf("Expected RateLimitRemaining 0, got %d", tooManyErr.RateLimitRemaining)
+	}
+	if !tooManyErr.RateLimitReset.Equal(time.Unix(1704110410, 0)) {
+		t.Errorf("Expected RateLimitReset %v, got %v", time.Unix(1704110410, 0), tooManyErr.RateLimitReset)
+	}
+
+	// The shared client is paused for other callers
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := rlHttpClient.gate.wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected gate to hold calls back, got %v", err)
+	}
+}
+
+func TestCall_HonorsRetryAfter(t *testing.T) {
+	var requestTimes []time.Time
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestTimes = append(requestTimes, time.Now())
+		if len(requestTimes) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("{}"))
+	}))
+	defer server.Close()
+
+	rl := rate.NewLimiter(rate.Every(1*time.Millisecond), 100)
+	rlHttpClient := NewRlHttpClient(rl, 5000)
+	rlHttpClient.RetryPolicy = &RetryPolicy{
+		MaxElapsedTime:  5 * time.Second,
+		InitialInterval: time.Millisecond,
+		MaxInterval:     time.Millisecond,
+		RetryOn:         RetryOnTooManyRequests,
+	}
+
+	err := Call(
+		context.Background(),
+		"test_api_key",
+		rlHttpClient,
+		"GET",
+		server.URL+"/test",
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(requestTimes) != 2 {
+		t.Fatalf("Expected 2 requests, got %d", len(requestTimes))
+	}
+	if gap := requestTimes[1].Sub(requestTimes[0]); gap < 900*time.Millisecond {
+		t.Errorf("Expected retry to wait for Retry-After, waited %v", gap)
+	}
+}
+
+func TestRlHttpClient_ThrottleOnExhaustedWindow(t *testing.T) {
+	now := time.Now()
+	rlHttpClient := NewRlHttpClient(rate.NewLimiter(rate.Every(1*time.Millisecond), 100), 5000)
+
+	response := &http.Response{Header: http.Header{}}
+	response.Header.Set("X-RateLimit-Remaining", "3")
+	response.Header.Set("X-RateLimit-Reset", "60")
+	rlHttpClient.throttle(response, now)
+	if err := rlHttpClient.gate.wait(context.Background()); err != nil {
+		t.Errorf("Expected no pause while requests remain, got %v", err)
+	}
+
+	response.Header.Set("X-RateLimit-Remaining", "0")
+	rlHttpClient.throttle(response, now)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := rlHttpClient.gate.wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected pause until window resets, got %v", err)
+	}
+}
+
+func TestCall_PreconditionFailedNotRetried(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusPreconditionFailed)
+		w.Write([]byte(`{"code":"PreconditionFailed","message":{"error":2400,"message":"The task is already completed."}}`))
+	}))
+	defer server.Close()
+
+	rl := rate.NewLimiter(rate.Every(1*time.Millisecond), 100)
+	rlHttpClient := NewRlHttpClient(rl, 5000)
+	rlHttpClient.RetryPolicy = &RetryPolicy{
+		MaxElapsedTime:  time.Second,
+		InitialInterval: time.Millisecond,
+		MaxInterval:     time.Millisecond,
+		RetryOn:         RetryOnTooManyRequests | RetryOnServerError,
+	}
+
+	err := Call(
+		context.Background(),
+		"test_api_key",
+		rlHttpClient,
+		"GET",
+		server.URL+"/test",
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	if !onfleet.IsPreconditionFailed(err) {
+		t.Fatalf("Expected a PreconditionFailed error, got %v", err)
+	}
+	if onfleet.IsTooManyRequests(err) {
+		t.Errorf("Expected a precondition failure not to be taken as throttling")
+	}
+	if requests != 1 {
+		t.Errorf("Expected 1 request, got %d", requests)
+	}
+}
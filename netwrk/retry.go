@@ -0,0 +1,135 @@
+package netwrk
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+
+	onfleet "github.com/onfleet/gonfleet"
+)
+
+// RetryCondition is a bit set of failures which may be retried.
+type RetryCondition uint
+
+const (
+	// RetryOnTooManyRequests retries throttled responses, see
+	// onfleet.TooManyRequestsError.
+	// The request was rejected before being processed so it is retried
+	// for every method.
+	RetryOnTooManyRequests RetryCondition = 1 << iota
+	// RetryOnServerError retries 5xx responses.
+	RetryOnServerError
+	// RetryOnConnectionReset retries connections closed or reset by the peer.
+	RetryOnConnectionReset
+	// RetryOnTimeout retries http client timeouts.
+	// Expiry of the caller's context is never retried.
+	RetryOnTimeout
+)
+
+// RetryPolicy controls how Call retries failed requests.
+//
+// Start from DefaultRetryPolicy and adjust. Zero MaxElapsedTime and
+// intervals fall back to their defaults, so retries always stop unless
+// MaxElapsedTime is negative.
+type RetryPolicy struct {
+	// MaxAttempts caps the number of attempts, including the first.
+	// 0 means no cap other than MaxElapsedTime.
+	MaxAttempts int
+	// MaxElapsedTime stops retrying once exceeded. Defaults to 15 seconds,
+	// negative means no limit.
+	MaxElapsedTime time.Duration
+	// InitialInterval is the wait before the first retry.
+	InitialInterval time.Duration
+	// MaxInterval caps the wait between retries.
+	MaxInterval time.Duration
+	// Jitter randomizes each wait by +/- Jitter * interval. Between 0 and 1.
+	Jitter float64
+	// RetryOn selects the conditions to retry.
+	RetryOn RetryCondition
+	// RetryNonIdempotent allows retrying POST requests on conditions other
+	// than RetryOnTooManyRequests, e.g. a Tasks.Create which hit a 5xx may
+	// have created the task and retrying it could create a duplicate.
+	RetryNonIdempotent bool
+}
+
+const defaultMaxElapsedTime = 15 * time.Second
+
+// DefaultRetryPolicy retries throttled requests for up to 15 seconds.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxElapsedTime:  defaultMaxElapsedTime,
+		InitialInterval: backoff.DefaultInitialInterval,
+		MaxInterval:     backoff.DefaultMaxInterval,
+		Jitter:          backoff.DefaultRandomizationFactor,
+		RetryOn:         RetryOnTooManyRequests,
+	}
+}
+
+// backOff builds the backoff schedule described by the policy.
+func (p RetryPolicy) backOff() backoff.BackOff {
+	exponentialBackOff := backoff.NewExponentialBackOff()
+	if p.InitialInterval > 0 {
+		exponentialBackOff.InitialInterval = p.InitialInterval
+	}
+	if p.MaxInterval > 0 {
+		exponentialBackOff.MaxInterval = p.MaxInterval
+	}
+	exponentialBackOff.RandomizationFactor = p.Jitter
+	switch {
+	case p.MaxElapsedTime > 0:
+		exponentialBackOff.MaxElapsedTime = p.MaxElapsedTime
+	case p.MaxElapsedTime == 0:
+		exponentialBackOff.MaxElapsedTime = defaultMaxElapsedTime
+	default:
+		// backoff treats 0 as no limit
+		exponentialBackOff.MaxElapsedTime = 0
+	}
+	exponentialBackOff.Reset()
+	var b backoff.BackOff = exponentialBackOff
+	if p.MaxAttempts > 0 {
+		b = backoff.WithMaxRetries(b, uint64(p.MaxAttempts-1))
+	}
+	return b
+}
+
+// shouldRetry reports whether a failed attempt may be retried.
+func (p RetryPolicy) shouldRetry(ctx context.Context, method string, statusCode int, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if errors.As(err, &onfleet.TooManyRequestsError{}) {
+		return p.RetryOn&RetryOnTooManyRequests != 0
+	}
+	if method == http.MethodPost && !p.RetryNonIdempotent {
+		return false
+	}
+	switch {
+	case statusCode >= 500:
+		return p.RetryOn&RetryOnServerError != 0
+	case statusCode != 0:
+		return false
+	case isConnectionReset(err):
+		return p.RetryOn&RetryOnConnectionReset != 0
+	case isTimeout(err):
+		return p.RetryOn&RetryOnTimeout != 0
+	}
+	return false
+}
+
+func isConnectionReset(err error) bool {
+	return errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+func isTimeout(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
@@ -0,0 +1,255 @@
+package netwrk
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"golang.org/x/time/rate"
+
+	onfleet "github.com/onfleet/gonfleet"
+)
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+var _ net.Error = timeoutError{}
+
+func TestRetryPolicy_ShouldRetry(t *testing.T) {
+	all := RetryOnTooManyRequests | RetryOnServerError | RetryOnConnectionReset | RetryOnTimeout
+	tests := []struct {
+		name       string
+		policy     RetryPolicy
+		method     string
+		statusCode int
+		err        error
+		expected   bool
+	}{
+		{
+			name:       "default retries throttling",
+			policy:     DefaultRetryPolicy(),
+			method:     http.MethodGet,
+			statusCode: http.StatusTooManyRequests,
+			err:        onfleet.TooManyRequestsError{},
+			expected:   true,
+		},
+		{
+			name:       "default retries throttled POST",
+			policy:     DefaultRetryPolicy(),
+			method:     http.MethodPost,
+			statusCode: http.StatusTooManyRequests,
+			err:        onfleet.TooManyRequestsError{},
+			expected:   true,
+		},
+		{
+			name:       "default does not retry 5xx",
+			policy:     DefaultRetryPolicy(),
+			method:     http.MethodGet,
+			statusCode: http.StatusBadGateway,
+			err:        onfleet.RequestError{},
+			expected:   false,
+		},
+		{
+			name:       "5xx GET",
+			policy:     RetryPolicy{RetryOn: all},
+			method:     http.MethodGet,
+			statusCode: http.StatusServiceUnavailable,
+			err:        onfleet.RequestError{},
+			expected:   true,
+		},
+		{
+			name:       "5xx POST without opt in",
+			policy:     RetryPolicy{RetryOn: all},
+			method:     http.MethodPost,
+			statusCode: http.StatusServiceUnavailable,
+			err:        onfleet.RequestError{},
+			expected:   false,
+		},
+		{
+			name:       "5xx POST with opt in",
+			policy:     RetryPolicy{RetryOn: all, RetryNonIdempotent: true},
+			method:     http.MethodPost,
+			statusCode: http.StatusServiceUnavailable,
+			err:        onfleet.RequestError{},
+			expected:   true,
+		},
+		{
+			name:       "4xx never",
+			policy:     RetryPolicy{RetryOn: all},
+			method:     http.MethodGet,
+			statusCode: http.StatusBadRequest,
+			err:        onfleet.RequestError{},
+			expected:   false,
+		},
+		{
+			name:     "connection reset",
+			policy:   RetryPolicy{RetryOn: RetryOnConnectionReset},
+			method:   http.MethodPut,
+			err:      &net.OpError{Op: "read", Err: syscall.ECONNRESET},
+			expected: true,
+		},
+		{
+			name:     "unexpected EOF",
+			policy:   RetryPolicy{RetryOn: RetryOnConnectionReset},
+			method:   http.MethodDelete,
+			err:      io.ErrUnexpectedEOF,
+			expected: true,
+		},
+		{
+			name:     "connection reset not selected",
+			policy:   RetryPolicy{RetryOn: RetryOnTimeout},
+			method:   http.MethodGet,
+			err:      io.EOF,
+			expected: false,
+		},
+		{
+			name:     "timeout",
+			policy:   RetryPolicy{RetryOn: RetryOnTimeout},
+			method:   http.MethodGet,
+			err:      timeoutError{},
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.policy.shouldRetry(context.Background(), tt.method, tt.statusCode, tt.err)
+			if result != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestRetryPolicy_ShouldRetry_ContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	policy := RetryPolicy{RetryOn: RetryOnTimeout | RetryOnTooManyRequests}
+	if policy.shouldRetry(ctx, http.MethodGet, 0, timeoutError{}) {
+		t.Error("Expected no retry once context is done")
+	}
+	if policy.shouldRetry(ctx, http.MethodGet, http.StatusTooManyRequests, onfleet.TooManyRequestsError{}) {
+		t.Error("Expected no retry once context is done")
+	}
+}
+
+func TestRetryPolicy_BackOffDefaults(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   RetryPolicy
+		expected time.Duration
+	}{
+		{name: "unset max elapsed time", policy: RetryPolicy{RetryOn: RetryOnServerError}, expected: 15 * time.Second},
+		{name: "set max elapsed time", policy: RetryPolicy{MaxElapsedTime: time.Second}, expected: time.Second},
+		{name: "negative max elapsed time", policy: RetryPolicy{MaxElapsedTime: -1}, expected: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, ok := tt.policy.backOff().(*backoff.ExponentialBackOff)
+			if !ok {
+				t.Fatalf("Expected an ExponentialBackOff, got %T", tt.policy.backOff())
+			}
+			if b.MaxElapsedTime != tt.expected {
+				t.Errorf("Expected max elapsed time %v, got %v", tt.expected, b.MaxElapsedTime)
+			}
+		})
+	}
+}
+
+func TestCall_RetryPolicy(t *testing.T) {
+	tests := []struct {
+		name             string
+		policy           *RetryPolicy
+		method           string
+		statusCode       int
+		expectedRequests int
+	}{
+		{
+			name:             "default policy does not retry 5xx",
+			policy:           nil,
+			method:           http.MethodGet,
+			statusCode:       http.StatusBadGateway,
+			expectedRequests: 1,
+		},
+		{
+			name: "max attempts on 5xx",
+			policy: &RetryPolicy{
+				MaxAttempts:     3,
+				InitialInterval: time.Millisecond,
+				MaxInterval:     time.Millisecond,
+				RetryOn:         RetryOnServerError,
+			},
+			method:           http.MethodGet,
+			statusCode:       http.StatusBadGateway,
+			expectedRequests: 3,
+		},
+		{
+			name: "POST excluded from 5xx retries",
+			policy: &RetryPolicy{
+				MaxAttempts:     3,
+				InitialInterval: time.Millisecond,
+				RetryOn:         RetryOnServerError,
+			},
+			method:           http.MethodPost,
+			statusCode:       http.StatusBadGateway,
+			expectedRequests: 1,
+		},
+		{
+			name: "max attempts on throttling",
+			policy: &RetryPolicy{
+				MaxAttempts:     2,
+				InitialInterval: time.Millisecond,
+				RetryOn:         RetryOnTooManyRequests,
+			},
+			method:           http.MethodPost,
+			statusCode:       http.StatusTooManyRequests,
+			expectedRequests: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requestCount := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requestCount++
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.statusCode)
+				json.NewEncoder(w).Encode(map[string]any{"code": "InternalError"})
+			}))
+			defer server.Close()
+
+			rl := rate.NewLimiter(rate.Every(1*time.Millisecond), 100)
+			rlHttpClient := NewRlHttpClient(rl, 5000)
+			rlHttpClient.RetryPolicy = tt.policy
+
+			err := Call(
+				context.Background(),
+				"test_api_key",
+				rlHttpClient,
+				tt.method,
+				server.URL+"/test",
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			if err == nil {
+				t.Error("Expected error")
+			}
+			if requestCount != tt.expectedRequests {
+				t.Errorf("Expected %d requests, got %d", tt.expectedRequests, requestCount)
+			}
+		})
+	}
+}
@@ -0,0 +1,7 @@
+package onfleet
+
+// Ptr returns a pointer to v, to set the optional fields of update params
+// e.g. onfleet.TaskParams{PickupTask: onfleet.Ptr(true)}.
+func Ptr[T any](v T) *T {
+	return &v
+}
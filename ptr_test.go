@@ -0,0 +1,34 @@
+package onfleet
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateParams_OnlySetFields(t *testing.T) {
+	tests := []struct {
+		name   string
+		params any
+		want   string
+	}{
+		{"team name only", TeamUpdateParams{Name: "North"}, `{"name":"North"}`},
+		{"team clear workers", TeamUpdateParams{Workers: &[]string{}, EnableSelfAssignment: Ptr(false)}, `{"enableSelfAssignment":false,"workers":[]}`},
+		{"task notes only", TaskParams{Notes: "gate code 1234"}, `{"notes":"gate code 1234"}`},
+		{"task unset pickup", TaskParams{PickupTask: Ptr(false), Quantity: Ptr(0.0)}, `{"pickupTask":false,"quantity":0}`},
+		{"clone overrides", TaskCloneOverridesParam{Notes: "copy"}, `{"notes":"copy"}`},
+		{"worker capacity", WorkerUpdateParams{Capacity: Ptr(0.0)}, `{"capacity":0}`},
+		{"recipient sms", RecipientUpdateParams{SkipSmsNotifications: Ptr(false)}, `{"skipSMSNotifications":false}`},
+		{"hub name only", HubUpdateParams{Name: "Depot"}, `{"name":"Depot"}`},
+		{"admin", AdminUpdateParams{}, `{}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.params)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(data))
+		})
+	}
+}
@@ -22,11 +22,13 @@ type RecipientCreateParams struct {
 	UseLongCodeForText        bool       `json:"useLongCodeForText,omitempty"`
 }
 
+// RecipientUpdateParams only sends the fields set, nil and empty fields are
+// left unchanged.
 type RecipientUpdateParams struct {
 	Metadata             []Metadata `json:"metadata,omitempty"`
 	Name                 string     `json:"name,omitempty"`
 	Notes                string     `json:"notes,omitempty"`
-	SkipSmsNotifications bool       `json:"skipSMSNotifications,omitempty"`
+	SkipSmsNotifications *bool      `json:"skipSMSNotifications,omitempty"`
 }
 
 type RecipientQueryKey string
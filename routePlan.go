@@ -1,5 +1,7 @@
 package onfleet
 
+import "time"
+
 type PositionEnum string
 
 const (
@@ -53,6 +55,22 @@ type RoutePlanListQueryParams struct {
 	CreatedTimeFrom int64  `json:"createdTimeFrom,omitempty"`
 	HasTasks        bool   `json:"hasTasks,omitempty"`
 	Limit           int64  `json:"limit,omitempty"`
+	// Used for pagination
+	LastId string `json:"lastId,omitempty"`
+}
+
+// SetStartTimeRange sets StartTimeFrom and StartTimeTo, zero times are
+// left unset.
+func (p *RoutePlanListQueryParams) SetStartTimeRange(from, to time.Time) {
+	p.StartTimeFrom = int64(FromTime(from))
+	p.StartTimeTo = int64(FromTime(to))
+}
+
+// SetCreatedTimeRange sets CreatedTimeFrom and CreatedTimeTo, zero times
+// are left unset.
+func (p *RoutePlanListQueryParams) SetCreatedTimeRange(from, to time.Time) {
+	p.CreatedTimeFrom = int64(FromTime(from))
+	p.CreatedTimeTo = int64(FromTime(to))
 }
 
 type RoutePlanAddTasksParams struct {
@@ -1,6 +1,7 @@
 package admin
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/onfleet/gonfleet"
@@ -25,8 +26,14 @@ func Plug(apiKey string, rlHttpClient *netwrk.RlHttpClient, url string, call net
 
 // Reference https://docs.onfleet.com/reference/list-administrators
 func (c *Client) List() ([]onfleet.Admin, error) {
+	return c.ListCtx(context.Background())
+}
+
+// Reference https://docs.onfleet.com/reference/list-administrators
+func (c *Client) ListCtx(ctx context.Context) ([]onfleet.Admin, error) {
 	admins := []onfleet.Admin{}
 	err := c.call(
+		ctx,
 		c.apiKey,
 		c.rlHttpClient,
 		http.MethodGet,
@@ -41,8 +48,14 @@ func (c *Client) List() ([]onfleet.Admin, error) {
 
 // Reference https://docs.onfleet.com/reference/querying-by-metadata
 func (c *Client) ListWithMetadataQuery(metadata []onfleet.Metadata) ([]onfleet.Admin, error) {
+	return c.ListWithMetadataQueryCtx(context.Background(), metadata)
+}
+
+// Reference https://docs.onfleet.com/reference/querying-by-metadata
+func (c *Client) ListWithMetadataQueryCtx(ctx context.Context, metadata []onfleet.Metadata) ([]onfleet.Admin, error) {
 	admins := []onfleet.Admin{}
 	err := c.call(
+		ctx,
 		c.apiKey,
 		c.rlHttpClient,
 		http.MethodPost,
@@ -57,8 +70,14 @@ func (c *Client) ListWithMetadataQuery(metadata []onfleet.Metadata) ([]onfleet.A
 
 // Reference https://docs.onfleet.com/reference/create-administrator
 func (c *Client) Create(params onfleet.AdminCreateParams) (onfleet.Admin, error) {
+	return c.CreateCtx(context.Background(), params)
+}
+
+// Reference https://docs.onfleet.com/reference/create-administrator
+func (c *Client) CreateCtx(ctx context.Context, params onfleet.AdminCreateParams) (onfleet.Admin, error) {
 	admin := onfleet.Admin{}
 	err := c.call(
+		ctx,
 		c.apiKey,
 		c.rlHttpClient,
 		http.MethodPost,
@@ -73,8 +92,14 @@ func (c *Client) Create(params onfleet.AdminCreateParams) (onfleet.Admin, error)
 
 // Reference https://docs.onfleet.com/reference/update-administrator
 func (c *Client) Update(adminId string, params onfleet.AdminUpdateParams) (onfleet.Admin, error) {
+	return c.UpdateCtx(context.Background(), adminId, params)
+}
+
+// Reference https://docs.onfleet.com/reference/update-administrator
+func (c *Client) UpdateCtx(ctx context.Context, adminId string, params onfleet.AdminUpdateParams) (onfleet.Admin, error) {
 	admin := onfleet.Admin{}
 	err := c.call(
+		ctx,
 		c.apiKey,
 		c.rlHttpClient,
 		http.MethodPut,
@@ -89,7 +114,13 @@ func (c *Client) Update(adminId string, params onfleet.AdminUpdateParams) (onfle
 
 // Reference https://docs.onfleet.com/reference/delete-administrator
 func (c *Client) Delete(adminId string) error {
+	return c.DeleteCtx(context.Background(), adminId)
+}
+
+// Reference https://docs.onfleet.com/reference/delete-administrator
+func (c *Client) DeleteCtx(ctx context.Context, adminId string) error {
 	err := c.call(
+		ctx,
 		c.apiKey,
 		c.rlHttpClient,
 		http.MethodDelete,
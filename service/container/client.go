@@ -1,6 +1,7 @@
 package container
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/onfleet/gonfleet"
@@ -25,8 +26,14 @@ func Plug(apiKey string, rlHttpClient *netwrk.RlHttpClient, url string, call net
 
 // Reference https://docs.onfleet.com/reference/get-container
 func (c *Client) Get(id string, key onfleet.ContainerQueryKey) (onfleet.Container, error) {
+	return c.GetCtx(context.Background(), id, key)
+}
+
+// Reference https://docs.onfleet.com/reference/get-container
+func (c *Client) GetCtx(ctx context.Context, id string, key onfleet.ContainerQueryKey) (onfleet.Container, error) {
 	container := onfleet.Container{}
 	err := c.call(
+		ctx,
 		c.apiKey,
 		c.rlHttpClient,
 		http.MethodGet,
@@ -43,8 +50,16 @@ func (c *Client) Get(id string, key onfleet.ContainerQueryKey) (onfleet.Containe
 //
 // Reference https://docs.onfleet.com/reference/update-tasks
 func (c *Client) InsertTasks(id string, key onfleet.ContainerQueryKey, params onfleet.ContainerTaskInsertParams) (onfleet.Container, error) {
+	return c.InsertTasksCtx(context.Background(), id, key, params)
+}
+
+// Reference https://docs.onfleet.com/reference/insert-tasks-at-index-or-append
+//
+// Reference https://docs.onfleet.com/reference/update-tasks
+func (c *Client) InsertTasksCtx(ctx context.Context, id string, key onfleet.ContainerQueryKey, params onfleet.ContainerTaskInsertParams) (onfleet.Container, error) {
 	container := onfleet.Container{}
 	err := c.call(
+		ctx,
 		c.apiKey,
 		c.rlHttpClient,
 		http.MethodPut,
@@ -0,0 +1,136 @@
+package customField
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/onfleet/gonfleet"
+	"github.com/onfleet/gonfleet/netwrk"
+)
+
+type Client struct {
+	apiKey       string
+	rlHttpClient *netwrk.RlHttpClient
+	url          string
+	call         netwrk.Caller
+}
+
+func Plug(apiKey string, rlHttpClient *netwrk.RlHttpClient, url string, call netwrk.Caller) *Client {
+	return &Client{
+		apiKey:       apiKey,
+		rlHttpClient: rlHttpClient,
+		url:          url,
+		call:         call,
+	}
+}
+
+// fieldParams is the body of the create, update and delete calls.
+type fieldParams struct {
+	Model string `json:"model"`
+	Field any    `json:"field"`
+}
+
+// Reference https://docs.onfleet.com/reference/get-custom-fields
+func (c *Client) List() ([]onfleet.CustomField, error) {
+	return c.ListCtx(context.Background())
+}
+
+// Reference https://docs.onfleet.com/reference/get-custom-fields
+func (c *Client) ListCtx(ctx context.Context) ([]onfleet.CustomField, error) {
+	response := struct {
+		Fields []onfleet.CustomField `json:"fields"`
+	}{Fields: []onfleet.CustomField{}}
+	err := c.call(
+		ctx,
+		c.apiKey,
+		c.rlHttpClient,
+		http.MethodGet,
+		c.url,
+		[]string{onfleet.CustomFieldModelTask},
+		nil,
+		nil,
+		&response,
+	)
+	return response.Fields, err
+}
+
+// Reference https://docs.onfleet.com/reference/create-custom-field
+func (c *Client) Create(field onfleet.CustomField) error {
+	return c.CreateCtx(context.Background(), field)
+}
+
+// Reference https://docs.onfleet.com/reference/create-custom-field
+func (c *Client) CreateCtx(ctx context.Context, field onfleet.CustomField) error {
+	return c.call(
+		ctx,
+		c.apiKey,
+		c.rlHttpClient,
+		http.MethodPost,
+		c.url,
+		[]string{onfleet.CustomFieldModelTask},
+		nil,
+		fieldParams{Model: onfleet.CustomFieldModelTask, Field: field},
+		nil,
+	)
+}
+
+// Reference https://docs.onfleet.com/reference/update-custom-field
+func (c *Client) Update(params onfleet.CustomFieldUpdateParams) error {
+	return c.UpdateCtx(context.Background(), params)
+}
+
+// Reference https://docs.onfleet.com/reference/update-custom-field
+func (c *Client) UpdateCtx(ctx context.Context, params onfleet.CustomFieldUpdateParams) error {
+	return c.call(
+		ctx,
+		c.apiKey,
+		c.rlHttpClient,
+		http.MethodPut,
+		c.url,
+		[]string{onfleet.CustomFieldModelTask},
+		nil,
+		fieldParams{Model: onfleet.CustomFieldModelTask, Field: params},
+		nil,
+	)
+}
+
+// Reference https://docs.onfleet.com/reference/delete-custom-field
+func (c *Client) Delete(key string) error {
+	return c.DeleteCtx(context.Background(), key)
+}
+
+// Reference https://docs.onfleet.com/reference/delete-custom-field
+func (c *Client) DeleteCtx(ctx context.Context, key string) error {
+	return c.call(
+		ctx,
+		c.apiKey,
+		c.rlHttpClient,
+		http.MethodDelete,
+		c.url,
+		[]string{onfleet.CustomFieldModelTask},
+		nil,
+		fieldParams{Model: onfleet.CustomFieldModelTask, Field: struct {
+			Key string `json:"key"`
+		}{Key: key}},
+		nil,
+	)
+}
+
+// Check lists the custom field definitions and checks params against them
+// with onfleet.ValidateCustomFields, e.g. TaskParams.CustomFields before
+// creating a task.
+func (c *Client) Check(params []onfleet.CustomFieldParams) error {
+	return c.CheckCtx(context.Background(), params)
+}
+
+// CheckCtx is Check with a context, which also bounds the list request.
+func (c *Client) CheckCtx(ctx context.Context, params []onfleet.CustomFieldParams) error {
+	if len(params) == 0 {
+		return nil
+	}
+	fields, err := c.ListCtx(ctx)
+	if err != nil {
+		return err
+	}
+	return onfleet.ValidateCustomFields(fields, params)
+}
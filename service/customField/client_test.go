@@ -0,0 +1,127 @@
+package customField
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onfleet/gonfleet"
+	"github.com/onfleet/gonfleet/netwrk"
+	"github.com/onfleet/gonfleet/testingutil"
+)
+
+// recordingCaller records the method, path and json body of every call.
+type recordingCaller struct {
+	method string
+	path   []string
+	body   string
+}
+
+func (r *recordingCaller) call(ctx context.Context, apiKey string, rlHttpClient *netwrk.RlHttpClient, method string, baseUrl string, pathSegments []string, queryParams any, body any, v any, additionalHeaders ...[2]string) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	r.method, r.path, r.body = method, pathSegments, string(data)
+	return nil
+}
+
+func sampleFields() []onfleet.CustomField {
+	return []onfleet.CustomField{
+		{Key: "gateCode", Name: "Gate code", Type: onfleet.CustomFieldValidDataTypeSingleLineText},
+		{Key: "boxes", Name: "Boxes", Type: onfleet.CustomFieldValidDataTypeInteger},
+		{Key: "deliverBy", Name: "Deliver by", Type: onfleet.CustomFieldValidDataTypeDate},
+	}
+}
+
+func TestClient_List(t *testing.T) {
+	mockClient := testingutil.SetupTest(t)
+	defer testingutil.CleanupTest(t, mockClient)
+
+	mockClient.AddResponse("/customFields/Task", testingutil.MockResponse{
+		StatusCode: 200,
+		Body:       map[string]any{"fields": sampleFields()},
+	})
+
+	client := Plug("test_api_key", nil, "https://api.example.com/customFields", mockClient.MockCaller)
+
+	fields, err := client.List()
+
+	assert.NoError(t, err)
+	assert.Equal(t, sampleFields(), fields)
+
+	mockClient.AssertRequestMade("GET", "/customFields/Task")
+	mockClient.AssertBasicAuth("test_api_key")
+}
+
+func TestClient_Create(t *testing.T) {
+	caller := &recordingCaller{}
+	client := Plug("test_api_key", nil, "https://api.example.com/customFields", caller.call)
+
+	err := client.Create(onfleet.CustomField{
+		Key:        "boxes",
+		Name:       "Boxes",
+		Type:       onfleet.CustomFieldValidDataTypeInteger,
+		Visibility: []onfleet.CustomFieldVisibilityOption{onfleet.CustomFieldVisibilityOptionAdmin},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "POST", caller.method)
+	assert.Equal(t, []string{"Task"}, caller.path)
+	assert.Contains(t, caller.body, `"model":"Task"`)
+	assert.Contains(t, caller.body, `"key":"boxes"`)
+	assert.Contains(t, caller.body, `"type":"integer"`)
+}
+
+func TestClient_Update(t *testing.T) {
+	caller := &recordingCaller{}
+	client := Plug("test_api_key", nil, "https://api.example.com/customFields", caller.call)
+
+	err := client.Update(onfleet.CustomFieldUpdateParams{Key: "boxes", Name: "Box count"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "PUT", caller.method)
+	assert.JSONEq(t, `{"model":"Task","field":{"key":"boxes","name":"Box count"}}`, caller.body)
+}
+
+func TestClient_Delete(t *testing.T) {
+	caller := &recordingCaller{}
+	client := Plug("test_api_key", nil, "https://api.example.com/customFields", caller.call)
+
+	err := client.Delete("boxes")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "DELETE", caller.method)
+	assert.Equal(t, []string{"Task"}, caller.path)
+	assert.JSONEq(t, `{"model":"Task","field":{"key":"boxes"}}`, caller.body)
+}
+
+func TestClient_Check(t *testing.T) {
+	mockClient := testingutil.SetupTest(t)
+	defer testingutil.CleanupTest(t, mockClient)
+
+	mockClient.AddResponse("/customFields/Task", testingutil.MockResponse{
+		StatusCode: 200,
+		Body:       map[string]any{"fields": sampleFields()},
+	})
+
+	client := Plug("test_api_key", nil, "https://api.example.com/customFields", mockClient.MockCaller)
+
+	assert.NoError(t, client.Check([]onfleet.CustomFieldParams{
+		onfleet.CustomFieldText("gateCode", "1234"),
+		onfleet.CustomFieldInteger("boxes", 3),
+	}))
+
+	err := client.Check([]onfleet.CustomFieldParams{{Key: "boxes", Value: "3"}})
+	var validationErr onfleet.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "customFields[0].value", validationErr.Errors[0].Field)
+
+	// nothing to check, nothing listed
+	mockClient.Reset()
+	assert.NoError(t, client.Check(nil))
+	assert.Empty(t, mockClient.RequestHistory)
+}
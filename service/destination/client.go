@@ -1,6 +1,7 @@
 package destination
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/onfleet/gonfleet"
@@ -25,8 +26,14 @@ func Plug(apiKey string, rlHttpClient *netwrk.RlHttpClient, url string, call net
 
 // Reference https://docs.onfleet.com/reference/get-single-destination
 func (c *Client) Get(destinationId string) (onfleet.Destination, error) {
+	return c.GetCtx(context.Background(), destinationId)
+}
+
+// Reference https://docs.onfleet.com/reference/get-single-destination
+func (c *Client) GetCtx(ctx context.Context, destinationId string) (onfleet.Destination, error) {
 	destination := onfleet.Destination{}
 	err := c.call(
+		ctx,
 		c.apiKey,
 		c.rlHttpClient,
 		http.MethodGet,
@@ -41,8 +48,14 @@ func (c *Client) Get(destinationId string) (onfleet.Destination, error) {
 
 // Reference https://docs.onfleet.com/reference/create-destination
 func (c *Client) Create(params onfleet.DestinationCreateParams) (onfleet.Destination, error) {
+	return c.CreateCtx(context.Background(), params)
+}
+
+// Reference https://docs.onfleet.com/reference/create-destination
+func (c *Client) CreateCtx(ctx context.Context, params onfleet.DestinationCreateParams) (onfleet.Destination, error) {
 	destination := onfleet.Destination{}
 	err := c.call(
+		ctx,
 		c.apiKey,
 		c.rlHttpClient,
 		http.MethodPost,
@@ -57,8 +70,14 @@ func (c *Client) Create(params onfleet.DestinationCreateParams) (onfleet.Destina
 
 // Reference https://docs.onfleet.com/reference/querying-by-metadata
 func (c *Client) ListWithMetadataQuery(metadata []onfleet.Metadata) ([]onfleet.Destination, error) {
+	return c.ListWithMetadataQueryCtx(context.Background(), metadata)
+}
+
+// Reference https://docs.onfleet.com/reference/querying-by-metadata
+func (c *Client) ListWithMetadataQueryCtx(ctx context.Context, metadata []onfleet.Metadata) ([]onfleet.Destination, error) {
 	destinations := []onfleet.Destination{}
 	err := c.call(
+		ctx,
 		c.apiKey,
 		c.rlHttpClient,
 		http.MethodPost,
@@ -1,6 +1,7 @@
 package hub
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/onfleet/gonfleet"
@@ -25,8 +26,14 @@ func Plug(apiKey string, rlHttpClient *netwrk.RlHttpClient, url string, call net
 
 // Reference https://docs.onfleet.com/reference/list-hubs
 func (c *Client) List() ([]onfleet.Hub, error) {
+	return c.ListCtx(context.Background())
+}
+
+// Reference https://docs.onfleet.com/reference/list-hubs
+func (c *Client) ListCtx(ctx context.Context) ([]onfleet.Hub, error) {
 	hubs := []onfleet.Hub{}
 	err := c.call(
+		ctx,
 		c.apiKey,
 		c.rlHttpClient,
 		http.MethodGet,
@@ -41,8 +48,14 @@ func (c *Client) List() ([]onfleet.Hub, error) {
 
 // Reference https://docs.onfleet.com/reference/create-hub
 func (c *Client) Create(params onfleet.HubCreateParams) (onfleet.Hub, error) {
+	return c.CreateCtx(context.Background(), params)
+}
+
+// Reference https://docs.onfleet.com/reference/create-hub
+func (c *Client) CreateCtx(ctx context.Context, params onfleet.HubCreateParams) (onfleet.Hub, error) {
 	hub := onfleet.Hub{}
 	err := c.call(
+		ctx,
 		c.apiKey,
 		c.rlHttpClient,
 		http.MethodPost,
@@ -57,8 +70,14 @@ func (c *Client) Create(params onfleet.HubCreateParams) (onfleet.Hub, error) {
 
 // Reference https://docs.onfleet.com/reference/update-hub
 func (c *Client) Update(hubId string, params onfleet.HubUpdateParams) (onfleet.Hub, error) {
+	return c.UpdateCtx(context.Background(), hubId, params)
+}
+
+// Reference https://docs.onfleet.com/reference/update-hub
+func (c *Client) UpdateCtx(ctx context.Context, hubId string, params onfleet.HubUpdateParams) (onfleet.Hub, error) {
 	hub := onfleet.Hub{}
 	err := c.call(
+		ctx,
 		c.apiKey,
 		c.rlHttpClient,
 		http.MethodPut,
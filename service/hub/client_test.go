@@ -84,14 +84,14 @@ func TestClient_Update(t *testing.T) {
 
 	params := onfleet.HubUpdateParams{
 		Name: "Updated Distribution Center",
-		Address: onfleet.DestinationAddress{
+		Address: &onfleet.DestinationAddress{
 			Street:     "789 Updated Street",
 			City:       "San Francisco",
 			State:      "CA",
 			PostalCode: "94105",
 			Country:    "US",
 		},
-		Teams: []string{"team_123", "team_456", "team_789"},
+		Teams: &[]string{"team_123", "team_456", "team_789"},
 	}
 
 	hub, err := client.Update("hub_123", params)
@@ -275,7 +275,7 @@ func TestClient_ErrorScenarios(t *testing.T) {
 			operation: func(client *Client) error {
 				_, err := client.Update("nonexistent", onfleet.HubUpdateParams{
 					Name: "Updated Hub",
-					Address: onfleet.DestinationAddress{
+					Address: &onfleet.DestinationAddress{
 						Street:  "123 Test Street",
 						City:    "Test City",
 						Country: "US",
@@ -1,6 +1,7 @@
 package organization
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/onfleet/gonfleet"
@@ -27,8 +28,14 @@ func Plug(apiKey string, rlHttpClient *netwrk.RlHttpClient, url string, altUrl s
 
 // Reference https://docs.onfleet.com/reference/get-details
 func (c *Client) Get() (onfleet.Organization, error) {
+	return c.GetCtx(context.Background())
+}
+
+// Reference https://docs.onfleet.com/reference/get-details
+func (c *Client) GetCtx(ctx context.Context) (onfleet.Organization, error) {
 	organization := onfleet.Organization{}
 	err := c.call(
+		ctx,
 		c.apiKey,
 		c.rlHttpClient,
 		http.MethodGet,
@@ -43,8 +50,14 @@ func (c *Client) Get() (onfleet.Organization, error) {
 
 // Reference https://docs.onfleet.com/reference/get-delegatee-details
 func (c *Client) GetDelegate(orgId string) (onfleet.OrganizationDelegate, error) {
+	return c.GetDelegateCtx(context.Background(), orgId)
+}
+
+// Reference https://docs.onfleet.com/reference/get-delegatee-details
+func (c *Client) GetDelegateCtx(ctx context.Context, orgId string) (onfleet.OrganizationDelegate, error) {
 	delegate := onfleet.OrganizationDelegate{}
 	err := c.call(
+		ctx,
 		c.apiKey,
 		c.rlHttpClient,
 		http.MethodGet,
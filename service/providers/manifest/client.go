@@ -1,6 +1,7 @@
 package manifest
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 
@@ -26,6 +27,11 @@ func Plug(apiKey string, rlHttpClient *netwrk.RlHttpClient, url string, call net
 
 // Reference https://docs.onfleet.com/reference/delivery-manifest
 func (c *Client) Generate(params *onfleet.ManifestGenerateParams, googleAPIKey string) (onfleet.DeliveryManifest, error) {
+	return c.GenerateCtx(context.Background(), params, googleAPIKey)
+}
+
+// Reference https://docs.onfleet.com/reference/delivery-manifest
+func (c *Client) GenerateCtx(ctx context.Context, params *onfleet.ManifestGenerateParams, googleAPIKey string) (onfleet.DeliveryManifest, error) {
 	deliveryManifest := onfleet.DeliveryManifest{}
 	hubId := params.HubId
 	workerId := params.WorkerId
@@ -43,6 +49,7 @@ func (c *Client) Generate(params *onfleet.ManifestGenerateParams, googleAPIKey s
 	}
 
 	err := c.call(
+		ctx,
 		c.apiKey,
 		c.rlHttpClient,
 		http.MethodPost,
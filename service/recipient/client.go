@@ -1,6 +1,7 @@
 package recipient
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/onfleet/gonfleet"
@@ -25,8 +26,14 @@ func Plug(apiKey string, rlHttpClient *netwrk.RlHttpClient, url string, call net
 
 // Reference https://docs.onfleet.com/reference/get-single-recipient
 func (c *Client) Get(recipientId string) (onfleet.Recipient, error) {
+	return c.GetCtx(context.Background(), recipientId)
+}
+
+// Reference https://docs.onfleet.com/reference/get-single-recipient
+func (c *Client) GetCtx(ctx context.Context, recipientId string) (onfleet.Recipient, error) {
 	recipient := onfleet.Recipient{}
 	err := c.call(
+		ctx,
 		c.apiKey,
 		c.rlHttpClient,
 		http.MethodGet,
@@ -41,8 +48,14 @@ func (c *Client) Get(recipientId string) (onfleet.Recipient, error) {
 
 // Reference https://docs.onfleet.com/reference/find-recipient
 func (c *Client) Find(value string, key onfleet.RecipientQueryKey) (onfleet.Recipient, error) {
+	return c.FindCtx(context.Background(), value, key)
+}
+
+// Reference https://docs.onfleet.com/reference/find-recipient
+func (c *Client) FindCtx(ctx context.Context, value string, key onfleet.RecipientQueryKey) (onfleet.Recipient, error) {
 	recipient := onfleet.Recipient{}
 	err := c.call(
+		ctx,
 		c.apiKey,
 		c.rlHttpClient,
 		http.MethodGet,
@@ -57,8 +70,14 @@ func (c *Client) Find(value string, key onfleet.RecipientQueryKey) (onfleet.Reci
 
 // Reference https://docs.onfleet.com/reference/update-recipient
 func (c *Client) Update(recipientId string, params onfleet.RecipientUpdateParams) (onfleet.Recipient, error) {
+	return c.UpdateCtx(context.Background(), recipientId, params)
+}
+
+// Reference https://docs.onfleet.com/reference/update-recipient
+func (c *Client) UpdateCtx(ctx context.Context, recipientId string, params onfleet.RecipientUpdateParams) (onfleet.Recipient, error) {
 	recipient := onfleet.Recipient{}
 	err := c.call(
+		ctx,
 		c.apiKey,
 		c.rlHttpClient,
 		http.MethodPut,
@@ -73,8 +92,14 @@ func (c *Client) Update(recipientId string, params onfleet.RecipientUpdateParams
 
 // Reference https://docs.onfleet.com/reference/create-recipient
 func (c *Client) Create(params onfleet.RecipientCreateParams) (onfleet.Recipient, error) {
+	return c.CreateCtx(context.Background(), params)
+}
+
+// Reference https://docs.onfleet.com/reference/create-recipient
+func (c *Client) CreateCtx(ctx context.Context, params onfleet.RecipientCreateParams) (onfleet.Recipient, error) {
 	recipient := onfleet.Recipient{}
 	err := c.call(
+		ctx,
 		c.apiKey,
 		c.rlHttpClient,
 		http.MethodPost,
@@ -89,8 +114,14 @@ func (c *Client) Create(params onfleet.RecipientCreateParams) (onfleet.Recipient
 
 // Reference https://docs.onfleet.com/reference/querying-by-metadata
 func (c *Client) ListWithMetadataQuery(metadata []onfleet.Metadata) ([]onfleet.Recipient, error) {
+	return c.ListWithMetadataQueryCtx(context.Background(), metadata)
+}
+
+// Reference https://docs.onfleet.com/reference/querying-by-metadata
+func (c *Client) ListWithMetadataQueryCtx(ctx context.Context, metadata []onfleet.Metadata) ([]onfleet.Recipient, error) {
 	recipients := []onfleet.Recipient{}
 	err := c.call(
+		ctx,
 		c.apiKey,
 		c.rlHttpClient,
 		http.MethodPost,
@@ -1,6 +1,7 @@
 package routePlan
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/onfleet/gonfleet"
@@ -25,8 +26,14 @@ func Plug(apiKey string, rlHttpClient *netwrk.RlHttpClient, url string, call net
 
 // Reference https://docs.onfleet.com/reference/post-create-route-plan
 func (c *Client) Create(params onfleet.RoutePlanParams) (onfleet.RoutePlan, error) {
+	return c.CreateCtx(context.Background(), params)
+}
+
+// Reference https://docs.onfleet.com/reference/post-create-route-plan
+func (c *Client) CreateCtx(ctx context.Context, params onfleet.RoutePlanParams) (onfleet.RoutePlan, error) {
 	routePlan := onfleet.RoutePlan{}
 	err := c.call(
+		ctx,
 		c.apiKey,
 		c.rlHttpClient,
 		http.MethodPost,
@@ -41,8 +48,14 @@ func (c *Client) Create(params onfleet.RoutePlanParams) (onfleet.RoutePlan, erro
 
 // Reference https://docs.onfleet.com/reference/update-route-plan
 func (c *Client) Update(routePlanId string, params onfleet.RoutePlanParams) (onfleet.RoutePlan, error) {
+	return c.UpdateCtx(context.Background(), routePlanId, params)
+}
+
+// Reference https://docs.onfleet.com/reference/update-route-plan
+func (c *Client) UpdateCtx(ctx context.Context, routePlanId string, params onfleet.RoutePlanParams) (onfleet.RoutePlan, error) {
 	routePlan := onfleet.RoutePlan{}
 	err := c.call(
+		ctx,
 		c.apiKey,
 		c.rlHttpClient,
 		http.MethodPut,
@@ -57,8 +70,14 @@ func (c *Client) Update(routePlanId string, params onfleet.RoutePlanParams) (onf
 
 // Reference https://docs.onfleet.com/reference/add-tasks-to-route-plan
 func (c *Client) AddTasks(routePlanId string, params onfleet.RoutePlanAddTasksParams) (onfleet.RoutePlan, error) {
+	return c.AddTasksCtx(context.Background(), routePlanId, params)
+}
+
+// Reference https://docs.onfleet.com/reference/add-tasks-to-route-plan
+func (c *Client) AddTasksCtx(ctx context.Context, routePlanId string, params onfleet.RoutePlanAddTasksParams) (onfleet.RoutePlan, error) {
 	routePlan := onfleet.RoutePlan{}
 	err := c.call(
+		ctx,
 		c.apiKey,
 		c.rlHttpClient,
 		http.MethodPut,
@@ -73,8 +92,14 @@ func (c *Client) AddTasks(routePlanId string, params onfleet.RoutePlanAddTasksPa
 
 // Reference https://docs.onfleet.com/reference/get-routeplan-by-id
 func (c *Client) Get(routePlanId string) (onfleet.RoutePlan, error) {
+	return c.GetCtx(context.Background(), routePlanId)
+}
+
+// Reference https://docs.onfleet.com/reference/get-routeplan-by-id
+func (c *Client) GetCtx(ctx context.Context, routePlanId string) (onfleet.RoutePlan, error) {
 	routePlan := onfleet.RoutePlan{}
 	err := c.call(
+		ctx,
 		c.apiKey,
 		c.rlHttpClient,
 		http.MethodGet,
@@ -89,8 +114,14 @@ func (c *Client) Get(routePlanId string) (onfleet.RoutePlan, error) {
 
 // Reference https://docs.onfleet.com/reference/get-route-plan
 func (c *Client) List(params onfleet.RoutePlanListQueryParams) (onfleet.RoutePlansPaginated, error) {
+	return c.ListCtx(context.Background(), params)
+}
+
+// Reference https://docs.onfleet.com/reference/get-route-plan
+func (c *Client) ListCtx(ctx context.Context, params onfleet.RoutePlanListQueryParams) (onfleet.RoutePlansPaginated, error) {
 	paginatedRoutePlans := onfleet.RoutePlansPaginated{}
 	err := c.call(
+		ctx,
 		c.apiKey,
 		c.rlHttpClient,
 		http.MethodGet,
@@ -105,7 +136,13 @@ func (c *Client) List(params onfleet.RoutePlanListQueryParams) (onfleet.RoutePla
 
 // Reference https://docs.onfleet.com/reference/delete-routePlan
 func (c *Client) Delete(routePlanId string) error {
+	return c.DeleteCtx(context.Background(), routePlanId)
+}
+
+// Reference https://docs.onfleet.com/reference/delete-routePlan
+func (c *Client) DeleteCtx(ctx context.Context, routePlanId string) error {
 	err := c.call(
+		ctx,
 		c.apiKey,
 		c.rlHttpClient,
 		http.MethodDelete,
@@ -117,3 +154,18 @@ func (c *Client) Delete(routePlanId string) error {
 	)
 	return err
 }
+
+// All iterates over every route plan matching params, following LastId.
+// opts.PageSize sets params.Limit when params.Limit is unset.
+//
+// Reference https://docs.onfleet.com/reference/get-route-plan
+func (c *Client) All(ctx context.Context, params onfleet.RoutePlanListQueryParams, opts *onfleet.IteratorOptions) *onfleet.Iterator[onfleet.RoutePlan] {
+	if opts != nil && opts.PageSize > 0 && params.Limit == 0 {
+		params.Limit = int64(opts.PageSize)
+	}
+	return onfleet.NewIterator(ctx, params.LastId, func(ctx context.Context, lastId string) ([]onfleet.RoutePlan, string, error) {
+		params.LastId = lastId
+		paginatedRoutePlans, err := c.ListCtx(ctx, params)
+		return paginatedRoutePlans.RoutePlans, paginatedRoutePlans.LastId, err
+	}, opts)
+}
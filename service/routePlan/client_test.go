@@ -1,10 +1,12 @@
 package routePlan
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/onfleet/gonfleet"
+	"github.com/onfleet/gonfleet/netwrk"
 	"github.com/onfleet/gonfleet/testingutil"
 )
 
@@ -473,4 +475,29 @@ func TestClient_ErrorScenarios(t *testing.T) {
 			assert.Error(t, err)
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestClient_All(t *testing.T) {
+	second := testingutil.GetSampleRoutePlan()
+	second.Id = "route_plan_456"
+
+	var limits []int64
+	caller := &testingutil.PaginatedCaller{
+		Pages: map[string]interface{}{
+			"":               onfleet.RoutePlansPaginated{LastId: "route_plan_123", RoutePlans: []onfleet.RoutePlan{testingutil.GetSampleRoutePlan()}},
+			"route_plan_123": onfleet.RoutePlansPaginated{RoutePlans: []onfleet.RoutePlan{second}},
+		},
+	}
+	client := Plug("test_api_key", nil, "https://api.example.com/routePlans", func(ctx context.Context, apiKey string, rlHttpClient *netwrk.RlHttpClient, method string, baseUrl string, pathSegments []string, queryParams any, body any, v any, additionalHeaders ...[2]string) error {
+		limits = append(limits, queryParams.(onfleet.RoutePlanListQueryParams).Limit)
+		return caller.Call(ctx, apiKey, rlHttpClient, method, baseUrl, pathSegments, queryParams, body, v, additionalHeaders...)
+	})
+
+	routePlans, err := client.All(context.Background(), onfleet.RoutePlanListQueryParams{}, &onfleet.IteratorOptions{PageSize: 1}).Collect()
+
+	assert.NoError(t, err)
+	assert.Len(t, routePlans, 2)
+	assert.Equal(t, "route_plan_456", routePlans[1].Id)
+	assert.Equal(t, []string{"", "route_plan_123"}, caller.LastIds)
+	assert.Equal(t, []int64{1, 1}, limits)
+}
@@ -0,0 +1,284 @@
+package task
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/onfleet/gonfleet"
+)
+
+const (
+	defaultChangeFeedInterval = 30 * time.Second
+	defaultChangeFeedLookback = 24 * time.Hour
+)
+
+// ChangeType is the kind of a Change.
+type ChangeType int
+
+const (
+	// ChangeCreated is a task seen for the first time.
+	ChangeCreated ChangeType = iota
+	// ChangeUpdated is a task modified without changing state.
+	ChangeUpdated
+	// ChangeStateChanged is a task which changed state, other than to
+	// completed.
+	ChangeStateChanged
+	// ChangeCompleted is a task which reached TaskStateCompleted, whether it
+	// succeeded or failed.
+	ChangeCompleted
+)
+
+func (t ChangeType) String() string {
+	switch t {
+	case ChangeCreated:
+		return "created"
+	case ChangeUpdated:
+		return "updated"
+	case ChangeStateChanged:
+		return "stateChanged"
+	case ChangeCompleted:
+		return "completed"
+	}
+	return fmt.Sprintf("ChangeType(%d)", int(t))
+}
+
+// Change is a task change found by a ChangeFeed.
+type Change struct {
+	Type ChangeType
+	Task onfleet.Task
+	// PreviousState is the state before a ChangeStateChanged or
+	// ChangeCompleted of a task seen before.
+	PreviousState onfleet.TaskState
+}
+
+// TaskSnapshot is what a ChangeFeed remembers of a task.
+type TaskSnapshot struct {
+	State            onfleet.TaskState `json:"state"`
+	TimeCreated      int64             `json:"timeCreated"`
+	TimeLastModified int64             `json:"timeLastModified"`
+}
+
+// ChangeCheckpoint is the state of a ChangeFeed, saved after every poll
+// whose changes were all handled.
+type ChangeCheckpoint struct {
+	// Time is when the last successful poll listed tasks up to, in ms.
+	// 0 before the first poll.
+	Time int64 `json:"time"`
+	// Tasks holds the tasks seen within the lookback window, by id.
+	Tasks map[string]TaskSnapshot `json:"tasks"`
+}
+
+// CheckpointStore persists a ChangeFeed's checkpoint between polls and
+// restarts.
+type CheckpointStore interface {
+	// Load returns the saved checkpoint, or a zero ChangeCheckpoint if none.
+	Load(ctx context.Context) (ChangeCheckpoint, error)
+	Save(ctx context.Context, checkpoint ChangeCheckpoint) error
+}
+
+// ChangeFeedOptions configures a ChangeFeed.
+type ChangeFeedOptions struct {
+	// Params filters the tasks listed, e.g. by Worker. From, To and LastId
+	// are set by the feed.
+	Params onfleet.TaskListQueryParams
+	// Interval is the wait between polls of Run. Defaults to 30 seconds.
+	Interval time.Duration
+	// Lookback is how far back each poll lists tasks. Changes to tasks
+	// older than Lookback are not seen. Defaults to 24 hours.
+	Lookback time.Duration
+	// Store persists the checkpoint. Defaults to a MemoryCheckpointStore,
+	// so a restarted feed emits every task in the lookback window as created.
+	Store CheckpointStore
+}
+
+// ChangeFeed polls the task list for changes, a replacement for webhooks
+// where they cannot be received. Tasks are compared against the checkpoint
+// by TimeLastModified and state. Deleted tasks are not reported.
+//
+// Delivery is at least once: the checkpoint is only saved once every change
+// of a poll was handled, so a failed or interrupted poll is repeated.
+type ChangeFeed struct {
+	client   *Client
+	params   onfleet.TaskListQueryParams
+	interval time.Duration
+	lookback time.Duration
+	store    CheckpointStore
+	now      func() time.Time
+}
+
+// ChangeFeed creates a ChangeFeed. opts may be nil.
+func (c *Client) ChangeFeed(opts *ChangeFeedOptions) *ChangeFeed {
+	f := &ChangeFeed{
+		client:   c,
+		interval: defaultChangeFeedInterval,
+		lookback: defaultChangeFeedLookback,
+		now:      time.Now,
+	}
+	if opts != nil {
+		f.params = opts.Params
+		if opts.Interval > 0 {
+			f.interval = opts.Interval
+		}
+		if opts.Lookback > 0 {
+			f.lookback = opts.Lookback
+		}
+		f.store = opts.Store
+	}
+	if f.store == nil {
+		f.store = &MemoryCheckpointStore{}
+	}
+	return f
+}
+
+// Run polls every Interval until ctx is done or a poll fails, returning
+// the error.
+func (f *ChangeFeed) Run(ctx context.Context, fn func(ctx context.Context, change Change) error) error {
+	ticker := time.NewTicker(f.interval)
+	defer ticker.Stop()
+	for {
+		if err := f.Poll(ctx, fn); err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll lists the tasks of the lookback window once, passing every change
+// since the checkpoint to fn, then saves the checkpoint.
+// An error from fn stops the poll without saving.
+func (f *ChangeFeed) Poll(ctx context.Context, fn func(ctx context.Context, change Change) error) error {
+	checkpoint, err := f.store.Load(ctx)
+	if err != nil {
+		return fmt.Errorf("loading change feed checkpoint: %w", err)
+	}
+	now := f.now().UnixMilli()
+	from := now - f.lookback.Milliseconds()
+
+	params := f.params
+	params.From = from
+	params.To = now
+	params.LastId = ""
+	tasks, err := f.client.All(ctx, params, nil).Collect()
+	if err != nil {
+		return err
+	}
+
+	next := ChangeCheckpoint{Time: now, Tasks: map[string]TaskSnapshot{}}
+	for id, snapshot := range checkpoint.Tasks {
+		if snapshot.TimeCreated >= from {
+			next.Tasks[id] = snapshot
+		}
+	}
+	for _, task := range tasks {
+		for _, change := range diffTask(checkpoint, task) {
+			if err := fn(ctx, change); err != nil {
+				return err
+			}
+		}
+		next.Tasks[task.ID] = TaskSnapshot{
+			State:            task.State,
+			TimeCreated:      task.TimeCreated,
+			TimeLastModified: task.TimeLastModified,
+		}
+	}
+	return f.store.Save(ctx, next)
+}
+
+// diffTask returns the changes of task since checkpoint.
+func diffTask(checkpoint ChangeCheckpoint, task onfleet.Task) []Change {
+	previous, seen := checkpoint.Tasks[task.ID]
+	if !seen {
+		if checkpoint.Time != 0 && task.TimeCreated <= checkpoint.Time {
+			// created before the last poll yet not listed by it, e.g. the
+			// lookback grew or the list params changed
+			if task.TimeLastModified <= checkpoint.Time {
+				return nil
+			}
+			return []Change{{Type: ChangeUpdated, Task: task, PreviousState: task.State}}
+		}
+		changes := []Change{{Type: ChangeCreated, Task: task, PreviousState: task.State}}
+		if task.State == onfleet.TaskStateCompleted {
+			changes = append(changes, Change{Type: ChangeCompleted, Task: task, PreviousState: task.State})
+		}
+		return changes
+	}
+	if task.TimeLastModified <= previous.TimeLastModified && task.State == previous.State {
+		return nil
+	}
+	change := Change{Type: ChangeUpdated, Task: task, PreviousState: previous.State}
+	if task.State != previous.State {
+		change.Type = ChangeStateChanged
+		if task.State == onfleet.TaskStateCompleted {
+			change.Type = ChangeCompleted
+		}
+	}
+	return []Change{change}
+}
+
+// MemoryCheckpointStore keeps the checkpoint in memory.
+type MemoryCheckpointStore struct {
+	mu         sync.Mutex
+	checkpoint ChangeCheckpoint
+}
+
+func (s *MemoryCheckpointStore) Load(ctx context.Context) (ChangeCheckpoint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.checkpoint, nil
+}
+
+func (s *MemoryCheckpointStore) Save(ctx context.Context, checkpoint ChangeCheckpoint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.checkpoint = checkpoint
+	return nil
+}
+
+// FileCheckpointStore keeps the checkpoint as json in the file at Path,
+// replaced atomically on Save.
+type FileCheckpointStore struct {
+	Path string
+}
+
+func (s FileCheckpointStore) Load(ctx context.Context) (ChangeCheckpoint, error) {
+	var checkpoint ChangeCheckpoint
+	data, err := os.ReadFile(s.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return checkpoint, nil
+	}
+	if err != nil {
+		return checkpoint, err
+	}
+	err = json.Unmarshal(data, &checkpoint)
+	return checkpoint, err
+}
+
+func (s FileCheckpointStore) Save(ctx context.Context, checkpoint ChangeCheckpoint) error {
+	data, err := json.Marshal(checkpoint)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.Path), filepath.Base(s.Path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.Path)
+}
@@ -0,0 +1,160 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onfleet/gonfleet"
+)
+
+type recordedChange struct {
+	Type          ChangeType
+	TaskId        string
+	PreviousState onfleet.TaskState
+}
+
+// pollChanges polls tasks once at now through a fresh feed sharing store.
+func pollChanges(t *testing.T, tasks []onfleet.Task, store CheckpointStore, now int64, fn func(ctx context.Context, change Change) error) ([]recordedChange, error) {
+	t.Helper()
+	var calls int32
+	client := Plug("test_api_key", nil, "https://api.example.com/tasks", windowCaller(tasks, &calls))
+	feed := client.ChangeFeed(&ChangeFeedOptions{Lookback: 10 * time.Second, Store: store})
+	feed.now = func() time.Time { return time.UnixMilli(now) }
+	var changes []recordedChange
+	err := feed.Poll(context.Background(), func(ctx context.Context, change Change) error {
+		if fn != nil {
+			if err := fn(ctx, change); err != nil {
+				return err
+			}
+		}
+		changes = append(changes, recordedChange{change.Type, change.Task.ID, change.PreviousState})
+		return nil
+	})
+	return changes, err
+}
+
+func TestChangeFeed_Poll(t *testing.T) {
+	store := &MemoryCheckpointStore{}
+	tasks := []onfleet.Task{
+		{ID: "a", TimeCreated: 1000, TimeLastModified: 1000, State: onfleet.TaskStateUnassigned},
+		{ID: "b", TimeCreated: 2000, TimeLastModified: 2500, State: onfleet.TaskStateCompleted},
+		{ID: "c", TimeCreated: 3000, TimeLastModified: 3000, State: onfleet.TaskStateAssigned},
+	}
+
+	changes, err := pollChanges(t, tasks, store, 5000, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []recordedChange{
+		{ChangeCreated, "a", onfleet.TaskStateUnassigned},
+		{ChangeCreated, "b", onfleet.TaskStateCompleted},
+		{ChangeCompleted, "b", onfleet.TaskStateCompleted},
+		{ChangeCreated, "c", onfleet.TaskStateAssigned},
+	}, changes)
+
+	// nothing changed
+	changes, err = pollChanges(t, tasks, store, 6000, nil)
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+
+	tasks = []onfleet.Task{
+		{ID: "a", TimeCreated: 1000, TimeLastModified: 6500, State: onfleet.TaskStateUnassigned},
+		{ID: "b", TimeCreated: 2000, TimeLastModified: 2500, State: onfleet.TaskStateCompleted},
+		{ID: "c", TimeCreated: 3000, TimeLastModified: 6600, State: onfleet.TaskStateActive},
+		{ID: "d", TimeCreated: 6700, TimeLastModified: 6700, State: onfleet.TaskStateUnassigned},
+	}
+	changes, err = pollChanges(t, tasks, store, 7000, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []recordedChange{
+		{ChangeUpdated, "a", onfleet.TaskStateUnassigned},
+		{ChangeStateChanged, "c", onfleet.TaskStateAssigned},
+		{ChangeCreated, "d", onfleet.TaskStateUnassigned},
+	}, changes)
+
+	tasks[2].State = onfleet.TaskStateCompleted
+	tasks[2].TimeLastModified = 7500
+	changes, err = pollChanges(t, tasks, store, 8000, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []recordedChange{{ChangeCompleted, "c", onfleet.TaskStateActive}}, changes)
+
+	// tasks outside the lookback are dropped from the checkpoint
+	checkpoint, _ := store.Load(context.Background())
+	assert.Len(t, checkpoint.Tasks, 4)
+	_, err = pollChanges(t, tasks, store, 12500, nil)
+	require.NoError(t, err)
+	checkpoint, _ = store.Load(context.Background())
+	assert.Len(t, checkpoint.Tasks, 2)
+	assert.Equal(t, int64(12500), checkpoint.Time)
+}
+
+func TestChangeFeed_AtLeastOnce(t *testing.T) {
+	store := &MemoryCheckpointStore{}
+	tasks := []onfleet.Task{
+		{ID: "a", TimeCreated: 1000, TimeLastModified: 1000},
+		{ID: "b", TimeCreated: 2000, TimeLastModified: 2000},
+	}
+	boom := errors.New("boom")
+
+	changes, err := pollChanges(t, tasks, store, 5000, func(ctx context.Context, change Change) error {
+		if change.Task.ID == "b" {
+			return boom
+		}
+		return nil
+	})
+	assert.ErrorIs(t, err, boom)
+	assert.Len(t, changes, 1)
+
+	// the failed poll is repeated, including the change already handled
+	changes, err = pollChanges(t, tasks, store, 6000, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []recordedChange{
+		{ChangeCreated, "a", 0},
+		{ChangeCreated, "b", 0},
+	}, changes)
+}
+
+func TestFileCheckpointStore(t *testing.T) {
+	store := FileCheckpointStore{Path: filepath.Join(t.TempDir(), "checkpoint.json")}
+
+	checkpoint, err := store.Load(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, checkpoint.Time)
+
+	tasks := []onfleet.Task{{ID: "a", TimeCreated: 1000, TimeLastModified: 1000}}
+	_, err = pollChanges(t, tasks, store, 5000, nil)
+	require.NoError(t, err)
+
+	checkpoint, err = store.Load(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, ChangeCheckpoint{
+		Time:  5000,
+		Tasks: map[string]TaskSnapshot{"a": {TimeCreated: 1000, TimeLastModified: 1000}},
+	}, checkpoint)
+
+	// a restarted feed continues from the file
+	changes, err := pollChanges(t, tasks, store, 6000, nil)
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+}
+
+func TestChangeFeed_Run(t *testing.T) {
+	var calls int32
+	tasks := []onfleet.Task{{ID: "a", TimeCreated: time.Now().UnixMilli() - 1000}}
+	client := Plug("test_api_key", nil, "https://api.example.com/tasks", windowCaller(tasks, &calls))
+	feed := client.ChangeFeed(&ChangeFeedOptions{Interval: time.Millisecond})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	var created int
+	err := feed.Run(ctx, func(ctx context.Context, change Change) error {
+		created++
+		return nil
+	})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, 1, created)
+	assert.Greater(t, calls, int32(1))
+}
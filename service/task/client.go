@@ -1,6 +1,7 @@
 package task
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/onfleet/gonfleet"
@@ -12,6 +13,10 @@ type Client struct {
 	rlHttpClient *netwrk.RlHttpClient
 	url          string
 	call         netwrk.Caller
+	// ValidateParams runs Validate on the params of Create, BatchCreate,
+	// BatchCreateAsync and Update, and on the overrides of Clone, returning
+	// its onfleet.ValidationError without calling the API.
+	ValidateParams bool
 }
 
 func Plug(apiKey string, rlHttpClient *netwrk.RlHttpClient, url string, call netwrk.Caller) *Client {
@@ -25,8 +30,14 @@ func Plug(apiKey string, rlHttpClient *netwrk.RlHttpClient, url string, call net
 
 // Reference https://docs.onfleet.com/reference/get-single-task
 func (c *Client) Get(taskId string) (onfleet.Task, error) {
+	return c.GetCtx(context.Background(), taskId)
+}
+
+// Reference https://docs.onfleet.com/reference/get-single-task
+func (c *Client) GetCtx(ctx context.Context, taskId string) (onfleet.Task, error) {
 	task := onfleet.Task{}
 	err := c.call(
+		ctx,
 		c.apiKey,
 		c.rlHttpClient,
 		http.MethodGet,
@@ -41,8 +52,14 @@ func (c *Client) Get(taskId string) (onfleet.Task, error) {
 
 // Reference https://docs.onfleet.com/reference/get-single-task-by-shortid
 func (c *Client) GetByShortId(taskShortId string) (onfleet.Task, error) {
+	return c.GetByShortIdCtx(context.Background(), taskShortId)
+}
+
+// Reference https://docs.onfleet.com/reference/get-single-task-by-shortid
+func (c *Client) GetByShortIdCtx(ctx context.Context, taskShortId string) (onfleet.Task, error) {
 	task := onfleet.Task{}
 	err := c.call(
+		ctx,
 		c.apiKey,
 		c.rlHttpClient,
 		http.MethodGet,
@@ -57,8 +74,14 @@ func (c *Client) GetByShortId(taskShortId string) (onfleet.Task, error) {
 
 // Reference https://docs.onfleet.com/reference/list-tasks
 func (c *Client) List(params onfleet.TaskListQueryParams) (onfleet.TasksPaginated, error) {
+	return c.ListCtx(context.Background(), params)
+}
+
+// Reference https://docs.onfleet.com/reference/list-tasks
+func (c *Client) ListCtx(ctx context.Context, params onfleet.TaskListQueryParams) (onfleet.TasksPaginated, error) {
 	paginatedTasks := onfleet.TasksPaginated{}
 	err := c.call(
+		ctx,
 		c.apiKey,
 		c.rlHttpClient,
 		http.MethodGet,
@@ -73,8 +96,14 @@ func (c *Client) List(params onfleet.TaskListQueryParams) (onfleet.TasksPaginate
 
 // Reference https://docs.onfleet.com/reference/querying-by-metadata
 func (c *Client) ListWithMetadataQuery(metadata []onfleet.Metadata) ([]onfleet.Task, error) {
+	return c.ListWithMetadataQueryCtx(context.Background(), metadata)
+}
+
+// Reference https://docs.onfleet.com/reference/querying-by-metadata
+func (c *Client) ListWithMetadataQueryCtx(ctx context.Context, metadata []onfleet.Metadata) ([]onfleet.Task, error) {
 	tasks := []onfleet.Task{}
 	err := c.call(
+		ctx,
 		c.apiKey,
 		c.rlHttpClient,
 		http.MethodPost,
@@ -89,8 +118,19 @@ func (c *Client) ListWithMetadataQuery(metadata []onfleet.Metadata) ([]onfleet.T
 
 // Reference https://docs.onfleet.com/reference/create-task
 func (c *Client) Create(params onfleet.TaskParams) (onfleet.Task, error) {
+	return c.CreateCtx(context.Background(), params)
+}
+
+// Reference https://docs.onfleet.com/reference/create-task
+func (c *Client) CreateCtx(ctx context.Context, params onfleet.TaskParams) (onfleet.Task, error) {
 	task := onfleet.Task{}
+	if c.ValidateParams {
+		if err := params.Validate(); err != nil {
+			return task, err
+		}
+	}
 	err := c.call(
+		ctx,
 		c.apiKey,
 		c.rlHttpClient,
 		http.MethodPost,
@@ -105,8 +145,19 @@ func (c *Client) Create(params onfleet.TaskParams) (onfleet.Task, error) {
 
 // Reference https://docs.onfleet.com/reference/create-tasks-in-batch
 func (c *Client) BatchCreate(params onfleet.TaskBatchCreateParams) (onfleet.TaskBatchCreateResponse, error) {
+	return c.BatchCreateCtx(context.Background(), params)
+}
+
+// Reference https://docs.onfleet.com/reference/create-tasks-in-batch
+func (c *Client) BatchCreateCtx(ctx context.Context, params onfleet.TaskBatchCreateParams) (onfleet.TaskBatchCreateResponse, error) {
 	batchTasks := onfleet.TaskBatchCreateResponse{}
+	if c.ValidateParams {
+		if err := params.Validate(); err != nil {
+			return batchTasks, err
+		}
+	}
 	err := c.call(
+		ctx,
 		c.apiKey,
 		c.rlHttpClient,
 		http.MethodPost,
@@ -121,8 +172,19 @@ func (c *Client) BatchCreate(params onfleet.TaskBatchCreateParams) (onfleet.Task
 
 // Reference https://docs.onfleet.com/reference/create-tasks-in-batch-async
 func (c *Client) BatchCreateAsync(params onfleet.TaskBatchCreateParams) (onfleet.TaskBatchCreateResponseAsync, error) {
+	return c.BatchCreateAsyncCtx(context.Background(), params)
+}
+
+// Reference https://docs.onfleet.com/reference/create-tasks-in-batch-async
+func (c *Client) BatchCreateAsyncCtx(ctx context.Context, params onfleet.TaskBatchCreateParams) (onfleet.TaskBatchCreateResponseAsync, error) {
 	batchRes := onfleet.TaskBatchCreateResponseAsync{}
+	if c.ValidateParams {
+		if err := params.Validate(); err != nil {
+			return batchRes, err
+		}
+	}
 	err := c.call(
+		ctx,
 		c.apiKey,
 		c.rlHttpClient,
 		http.MethodPost,
@@ -137,8 +199,14 @@ func (c *Client) BatchCreateAsync(params onfleet.TaskBatchCreateParams) (onfleet
 
 // Reference https://docs.onfleet.com/reference/batch-job-status
 func (c *Client) GetBatchJobStatus(batchJobId string) (onfleet.TaskBatchStatusResponseAsync, error) {
+	return c.GetBatchJobStatusCtx(context.Background(), batchJobId)
+}
+
+// Reference https://docs.onfleet.com/reference/batch-job-status
+func (c *Client) GetBatchJobStatusCtx(ctx context.Context, batchJobId string) (onfleet.TaskBatchStatusResponseAsync, error) {
 	batchStatus := onfleet.TaskBatchStatusResponseAsync{}
 	err := c.call(
+		ctx,
 		c.apiKey,
 		c.rlHttpClient,
 		http.MethodGet,
@@ -153,8 +221,19 @@ func (c *Client) GetBatchJobStatus(batchJobId string) (onfleet.TaskBatchStatusRe
 
 // Reference https://docs.onfleet.com/reference/update-task
 func (c *Client) Update(taskId string, params onfleet.TaskParams) (onfleet.Task, error) {
+	return c.UpdateCtx(context.Background(), taskId, params)
+}
+
+// Reference https://docs.onfleet.com/reference/update-task
+func (c *Client) UpdateCtx(ctx context.Context, taskId string, params onfleet.TaskParams) (onfleet.Task, error) {
 	task := onfleet.Task{}
+	if c.ValidateParams {
+		if err := params.Validate(); err != nil {
+			return task, err
+		}
+	}
 	err := c.call(
+		ctx,
 		c.apiKey,
 		c.rlHttpClient,
 		http.MethodPut,
@@ -169,7 +248,13 @@ func (c *Client) Update(taskId string, params onfleet.TaskParams) (onfleet.Task,
 
 // Reference https://docs.onfleet.com/reference/complete-task
 func (c *Client) ForceComplete(taskId string, params onfleet.TaskForceCompletionParams) error {
+	return c.ForceCompleteCtx(context.Background(), taskId, params)
+}
+
+// Reference https://docs.onfleet.com/reference/complete-task
+func (c *Client) ForceCompleteCtx(ctx context.Context, taskId string, params onfleet.TaskForceCompletionParams) error {
 	err := c.call(
+		ctx,
 		c.apiKey,
 		c.rlHttpClient,
 		http.MethodPost,
@@ -184,8 +269,19 @@ func (c *Client) ForceComplete(taskId string, params onfleet.TaskForceCompletion
 
 // Reference https://docs.onfleet.com/reference/clone-task
 func (c *Client) Clone(taskId string, params *onfleet.TaskCloneParams) (onfleet.Task, error) {
+	return c.CloneCtx(context.Background(), taskId, params)
+}
+
+// Reference https://docs.onfleet.com/reference/clone-task
+func (c *Client) CloneCtx(ctx context.Context, taskId string, params *onfleet.TaskCloneParams) (onfleet.Task, error) {
 	task := onfleet.Task{}
+	if c.ValidateParams && params != nil && params.Overrides != nil {
+		if err := params.Overrides.Validate(); err != nil {
+			return task, err
+		}
+	}
 	err := c.call(
+		ctx,
 		c.apiKey,
 		c.rlHttpClient,
 		http.MethodPost,
@@ -200,7 +296,13 @@ func (c *Client) Clone(taskId string, params *onfleet.TaskCloneParams) (onfleet.
 
 // Reference https://docs.onfleet.com/reference/delete-task
 func (c *Client) Delete(taskId string) error {
+	return c.DeleteCtx(context.Background(), taskId)
+}
+
+// Reference https://docs.onfleet.com/reference/delete-task
+func (c *Client) DeleteCtx(ctx context.Context, taskId string) error {
 	err := c.call(
+		ctx,
 		c.apiKey,
 		c.rlHttpClient,
 		http.MethodDelete,
@@ -215,8 +317,14 @@ func (c *Client) Delete(taskId string) error {
 
 // Reference https://docs.onfleet.com/reference/automatically-assign-list-of-tasks
 func (c *Client) AutoAssignMulti(params onfleet.TaskAutoAssignMultiParams) (onfleet.TaskAutoAssignMultiResponse, error) {
+	return c.AutoAssignMultiCtx(context.Background(), params)
+}
+
+// Reference https://docs.onfleet.com/reference/automatically-assign-list-of-tasks
+func (c *Client) AutoAssignMultiCtx(ctx context.Context, params onfleet.TaskAutoAssignMultiParams) (onfleet.TaskAutoAssignMultiResponse, error) {
 	autoAssignMulti := onfleet.TaskAutoAssignMultiResponse{}
 	err := c.call(
+		ctx,
 		c.apiKey,
 		c.rlHttpClient,
 		http.MethodPost,
@@ -228,3 +336,15 @@ func (c *Client) AutoAssignMulti(params onfleet.TaskAutoAssignMultiParams) (onfl
 	)
 	return autoAssignMulti, err
 }
+
+// All iterates over every task matching params, following LastId.
+// opts.PageSize is ignored, the API has a fixed page size.
+//
+// Reference https://docs.onfleet.com/reference/list-tasks
+func (c *Client) All(ctx context.Context, params onfleet.TaskListQueryParams, opts *onfleet.IteratorOptions) *onfleet.Iterator[onfleet.Task] {
+	return onfleet.NewIterator(ctx, params.LastId, func(ctx context.Context, lastId string) ([]onfleet.Task, string, error) {
+		params.LastId = lastId
+		paginatedTasks, err := c.ListCtx(ctx, params)
+		return paginatedTasks.Tasks, paginatedTasks.LastId, err
+	}, opts)
+}
@@ -1,6 +1,7 @@
 package task
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -50,9 +51,50 @@ func TestClient_Get_NotFound(t *testing.T) {
 	task, err := client.Get("nonexistent")
 
 	assert.Error(t, err)
+	assert.True(t, onfleet.IsNotFound(err))
 	assert.Equal(t, "", task.ID) // Empty task on error
 }
 
+func TestClient_GetCtx(t *testing.T) {
+	mockClient := testingutil.SetupTest(t)
+	defer testingutil.CleanupTest(t, mockClient)
+
+	expectedTask := testingutil.GetSampleTask()
+	mockClient.AddResponse("/tasks/task_123", testingutil.MockResponse{
+		StatusCode: 200,
+		Body:       expectedTask,
+	})
+
+	client := Plug("test_api_key", nil, "https://api.example.com/tasks", mockClient.MockCaller)
+
+	type ctxKey struct{}
+	ctx := context.WithValue(context.Background(), ctxKey{}, "request-scoped")
+	task, err := client.GetCtx(ctx, "task_123")
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedTask.ID, task.ID)
+	assert.Equal(t, "request-scoped", mockClient.GetLastRequest().Context().Value(ctxKey{}))
+}
+
+func TestClient_GetCtx_Canceled(t *testing.T) {
+	mockClient := testingutil.SetupTest(t)
+	defer testingutil.CleanupTest(t, mockClient)
+
+	mockClient.AddResponse("/tasks/task_123", testingutil.MockResponse{
+		StatusCode: 200,
+		Body:       testingutil.GetSampleTask(),
+	})
+
+	client := Plug("test_api_key", nil, "https://api.example.com/tasks", mockClient.MockCaller)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	task, err := client.GetCtx(ctx, "task_123")
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, "", task.ID)
+}
+
 func TestClient_GetByShortId(t *testing.T) {
 	mockClient := testingutil.SetupTest(t)
 	defer testingutil.CleanupTest(t, mockClient)
@@ -178,7 +220,7 @@ func TestClient_Create_ValidationError(t *testing.T) {
 
 	// Invalid params - missing required fields
 	params := onfleet.TaskParams{
-		PickupTask: false,
+		PickupTask: onfleet.Ptr(false),
 		// Missing destination and recipients
 	}
 
@@ -188,6 +230,40 @@ func TestClient_Create_ValidationError(t *testing.T) {
 	assert.Equal(t, "", task.ID)
 }
 
+func TestClient_Create_ValidateParams(t *testing.T) {
+	mockClient := testingutil.SetupTest(t)
+	defer testingutil.CleanupTest(t, mockClient)
+
+	mockClient.AddResponse("/tasks", testingutil.MockResponse{
+		StatusCode: 201,
+		Body:       testingutil.GetSampleTask(),
+	})
+
+	client := Plug("test_api_key", nil, "https://api.example.com/tasks", mockClient.MockCaller)
+	client.ValidateParams = true
+
+	_, err := client.Create(testingutil.GetSampleTaskParams())
+	assert.NoError(t, err)
+
+	params := testingutil.GetSampleTaskParams()
+	params.CompleteAfter, params.CompleteBefore = params.CompleteBefore, params.CompleteAfter
+	_, err = client.Create(params)
+
+	var validationError onfleet.ValidationError
+	assert.ErrorAs(t, err, &validationError)
+	assert.Equal(t, "completeAfter", validationError.Errors[0].Field)
+	// rejected before reaching the API
+	assert.Equal(t, 1, mockClient.GetRequestCount())
+
+	_, err = client.BatchCreate(onfleet.TaskBatchCreateParams{Tasks: []onfleet.TaskParams{params}})
+	assert.ErrorContains(t, err, "tasks[0].completeAfter")
+	assert.Equal(t, 1, mockClient.GetRequestCount())
+
+	_, err = client.Clone("task_1", &onfleet.TaskCloneParams{Overrides: &onfleet.TaskCloneOverridesParam{Destination: 42}})
+	assert.ErrorContains(t, err, "destination: must be a destination id")
+	assert.Equal(t, 1, mockClient.GetRequestCount())
+}
+
 func TestClient_BatchCreate(t *testing.T) {
 	mockClient := testingutil.SetupTest(t)
 	defer testingutil.CleanupTest(t, mockClient)
@@ -560,4 +636,43 @@ func TestClient_DifferentConfigurations(t *testing.T) {
 			mockClient.AssertBasicAuth(tt.apiKey)
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestClient_All(t *testing.T) {
+	first := testingutil.GetSampleTask()
+	second := testingutil.GetSampleTask()
+	second.ID = "task_456"
+	third := testingutil.GetSampleTask()
+	third.ID = "task_789"
+
+	caller := &testingutil.PaginatedCaller{
+		Pages: map[string]interface{}{
+			"":         onfleet.TasksPaginated{LastId: "task_456", Tasks: []onfleet.Task{first, second}},
+			"task_456": onfleet.TasksPaginated{Tasks: []onfleet.Task{third}},
+		},
+	}
+	client := Plug("test_api_key", nil, "https://api.example.com/tasks", caller.Call)
+
+	tasks, err := client.All(context.Background(), onfleet.TaskListQueryParams{From: 1}, nil).Collect()
+
+	assert.NoError(t, err)
+	assert.Len(t, tasks, 3)
+	assert.Equal(t, "task_789", tasks[2].ID)
+	assert.Equal(t, []string{"", "task_456"}, caller.LastIds)
+}
+
+func TestClient_All_MaxItems(t *testing.T) {
+	caller := &testingutil.PaginatedCaller{
+		Pages: map[string]interface{}{
+			"":         onfleet.TasksPaginated{LastId: "task_456", Tasks: []onfleet.Task{testingutil.GetSampleTask()}},
+			"task_456": onfleet.TasksPaginated{Tasks: []onfleet.Task{testingutil.GetSampleTask()}},
+		},
+	}
+	client := Plug("test_api_key", nil, "https://api.example.com/tasks", caller.Call)
+
+	tasks, err := client.All(context.Background(), onfleet.TaskListQueryParams{From: 1}, &onfleet.IteratorOptions{MaxItems: 1}).Collect()
+
+	assert.NoError(t, err)
+	assert.Len(t, tasks, 1)
+	assert.Equal(t, []string{""}, caller.LastIds)
+}
@@ -0,0 +1,170 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/onfleet/gonfleet"
+)
+
+const (
+	defaultExportWindow      = 24 * time.Hour
+	defaultExportConcurrency = 4
+)
+
+// ExportOptions configures Export.
+type ExportOptions struct {
+	// Window is the time span of each slice of the From / To range.
+	// Defaults to 24 hours.
+	Window time.Duration
+	// Concurrency is the number of windows fetched at once. Defaults to 4.
+	// All windows share the client's rate limit.
+	Concurrency int
+	// Progress, if set, is called each time a window is fully fetched.
+	Progress func(ExportProgress)
+}
+
+// ExportProgress reports how far an Export has got.
+type ExportProgress struct {
+	WindowsDone  int
+	WindowsTotal int
+	// Tasks is the number of unique tasks passed to the callback so far.
+	Tasks int
+	// Duplicates is the number of tasks skipped as already seen in an
+	// overlapping window.
+	Duplicates int
+}
+
+// Export fetches every task matching params by splitting the From / To range
+// into windows which are listed concurrently. Each task is passed to fn once,
+// de-duplicated by ID. fn and Progress are never called concurrently.
+//
+// params.To defaults to now. params.LastId is ignored.
+// The first error, from the API or fn, stops the export and is returned.
+func (c *Client) Export(ctx context.Context, params onfleet.TaskListQueryParams, opts *ExportOptions, fn func(onfleet.Task) error) error {
+	window := defaultExportWindow
+	concurrency := defaultExportConcurrency
+	var progressFn func(ExportProgress)
+	if opts != nil {
+		if opts.Window > 0 {
+			window = opts.Window
+		}
+		if opts.Concurrency > 0 {
+			concurrency = opts.Concurrency
+		}
+		progressFn = opts.Progress
+	}
+	if params.From <= 0 {
+		return errors.New("task export: From is required")
+	}
+	to := params.To
+	if to == 0 {
+		to = time.Now().UnixMilli()
+	}
+	if to < params.From {
+		return errors.New("task export: To is before From")
+	}
+	windows := exportWindows(params.From, to, window.Milliseconds())
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu       sync.Mutex
+		seen     = map[string]struct{}{}
+		progress = ExportProgress{WindowsTotal: len(windows)}
+		firstErr error
+	)
+	fail := func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if firstErr == nil {
+			firstErr = err
+			cancel()
+		}
+	}
+	emit := func(task onfleet.Task) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if _, ok := seen[task.ID]; ok {
+			progress.Duplicates++
+			return nil
+		}
+		seen[task.ID] = struct{}{}
+		if err := fn(task); err != nil {
+			return err
+		}
+		progress.Tasks++
+		return nil
+	}
+	windowDone := func() {
+		mu.Lock()
+		defer mu.Unlock()
+		progress.WindowsDone++
+		if progressFn != nil {
+			progressFn(progress)
+		}
+	}
+
+	jobs := make(chan [2]int64)
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for w := range jobs {
+				windowParams := params
+				windowParams.From = w[0]
+				windowParams.To = w[1]
+				windowParams.LastId = ""
+				it := c.All(ctx, windowParams, nil)
+				for it.Next() {
+					if err := emit(it.Value()); err != nil {
+						fail(err)
+						return
+					}
+				}
+				if err := it.Err(); err != nil {
+					fail(err)
+					return
+				}
+				windowDone()
+			}
+		}()
+	}
+
+feed:
+	for _, w := range windows {
+		select {
+		case jobs <- w:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	return ctx.Err()
+}
+
+// exportWindows splits [from, to] into consecutive windows of size ms.
+// Neighbouring windows share their boundary.
+func exportWindows(from int64, to int64, size int64) [][2]int64 {
+	if size <= 0 {
+		size = to - from
+	}
+	var windows [][2]int64
+	for start := from; ; start += size {
+		end := start + size
+		if end >= to || size == 0 {
+			windows = append(windows, [2]int64{start, to})
+			return windows
+		}
+		windows = append(windows, [2]int64{start, end})
+	}
+}
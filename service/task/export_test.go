@@ -0,0 +1,131 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/onfleet/gonfleet"
+	"github.com/onfleet/gonfleet/netwrk"
+)
+
+// windowCaller serves tasks created within the inclusive From / To range of
+// each call, two per page.
+func windowCaller(tasks []onfleet.Task, calls *int32) netwrk.Caller {
+	return func(ctx context.Context, apiKey string, rlHttpClient *netwrk.RlHttpClient, method string, baseUrl string, pathSegments []string, queryParams any, body any, v any, additionalHeaders ...[2]string) error {
+		atomic.AddInt32(calls, 1)
+		params := queryParams.(onfleet.TaskListQueryParams)
+		var matching []onfleet.Task
+		for _, task := range tasks {
+			if task.TimeCreated >= params.From && task.TimeCreated <= params.To {
+				matching = append(matching, task)
+			}
+		}
+		start := 0
+		for i, task := range matching {
+			if task.ID == params.LastId {
+				start = i + 1
+			}
+		}
+		end := start + 2
+		page := onfleet.TasksPaginated{}
+		if end < len(matching) {
+			page.LastId = matching[end-1].ID
+		} else {
+			end = len(matching)
+		}
+		page.Tasks = matching[start:end]
+		*v.(*onfleet.TasksPaginated) = page
+		return nil
+	}
+}
+
+func exportFixture(n int, step int64) []onfleet.Task {
+	tasks := make([]onfleet.Task, n)
+	for i := range tasks {
+		tasks[i] = onfleet.Task{
+			ID:          string(rune('a'+i/26)) + string(rune('a'+i%26)),
+			TimeCreated: 1000 + int64(i)*step,
+		}
+	}
+	return tasks
+}
+
+func TestExportWindows(t *testing.T) {
+	assert.Equal(t, [][2]int64{{0, 10}, {10, 20}, {20, 25}}, exportWindows(0, 25, 10))
+	assert.Equal(t, [][2]int64{{0, 10}}, exportWindows(0, 10, 10))
+	assert.Equal(t, [][2]int64{{0, 5}}, exportWindows(0, 5, 10))
+	assert.Equal(t, [][2]int64{{5, 5}}, exportWindows(5, 5, 0))
+}
+
+func TestClient_Export(t *testing.T) {
+	tasks := exportFixture(40, 100) // created 1000 .. 4900, some on window boundaries
+	var calls int32
+	client := Plug("test_api_key", nil, "https://api.example.com/tasks", windowCaller(tasks, &calls))
+
+	var (
+		mu       sync.Mutex
+		got      = map[string]int{}
+		progress []ExportProgress
+	)
+	err := client.Export(context.Background(), onfleet.TaskListQueryParams{From: 1000, To: 4900}, &ExportOptions{
+		Window:      500 * time.Millisecond,
+		Concurrency: 3,
+		Progress: func(p ExportProgress) {
+			progress = append(progress, p)
+		},
+	}, func(task onfleet.Task) error {
+		mu.Lock()
+		defer mu.Unlock()
+		got[task.ID]++
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, got, 40)
+	for id, count := range got {
+		assert.Equal(t, 1, count, "task %s emitted more than once", id)
+	}
+	assert.Len(t, progress, 8)
+	last := progress[len(progress)-1]
+	assert.Equal(t, 8, last.WindowsDone)
+	assert.Equal(t, 8, last.WindowsTotal)
+	assert.Equal(t, 40, last.Tasks)
+	assert.Equal(t, 7, last.Duplicates) // one task on each inner boundary
+	assert.Greater(t, int(atomic.LoadInt32(&calls)), 8)
+}
+
+func TestClient_Export_CallbackError(t *testing.T) {
+	tasks := exportFixture(40, 100)
+	var calls int32
+	client := Plug("test_api_key", nil, "https://api.example.com/tasks", windowCaller(tasks, &calls))
+
+	stop := errors.New("stop")
+	seen := 0
+	err := client.Export(context.Background(), onfleet.TaskListQueryParams{From: 1000, To: 4900}, &ExportOptions{
+		Window:      500 * time.Millisecond,
+		Concurrency: 2,
+	}, func(task onfleet.Task) error {
+		seen++
+		if seen == 3 {
+			return stop
+		}
+		return nil
+	})
+
+	assert.ErrorIs(t, err, stop)
+	assert.Equal(t, 3, seen)
+}
+
+func TestClient_Export_RequiresFrom(t *testing.T) {
+	client := Plug("test_api_key", nil, "https://api.example.com/tasks", nil)
+
+	err := client.Export(context.Background(), onfleet.TaskListQueryParams{}, nil, func(onfleet.Task) error { return nil })
+
+	assert.Error(t, err)
+}
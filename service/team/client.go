@@ -1,6 +1,7 @@
 package team
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/onfleet/gonfleet"
@@ -25,8 +26,14 @@ func Plug(apiKey string, rlHttpClient *netwrk.RlHttpClient, url string, call net
 
 // Reference https://docs.onfleet.com/reference/get-single-team
 func (c *Client) Get(teamId string) (onfleet.Team, error) {
+	return c.GetCtx(context.Background(), teamId)
+}
+
+// Reference https://docs.onfleet.com/reference/get-single-team
+func (c *Client) GetCtx(ctx context.Context, teamId string) (onfleet.Team, error) {
 	team := onfleet.Team{}
 	err := c.call(
+		ctx,
 		c.apiKey,
 		c.rlHttpClient,
 		http.MethodGet,
@@ -41,8 +48,14 @@ func (c *Client) Get(teamId string) (onfleet.Team, error) {
 
 // Reference https://docs.onfleet.com/reference/list-teams
 func (c *Client) List() ([]onfleet.Team, error) {
+	return c.ListCtx(context.Background())
+}
+
+// Reference https://docs.onfleet.com/reference/list-teams
+func (c *Client) ListCtx(ctx context.Context) ([]onfleet.Team, error) {
 	teams := []onfleet.Team{}
 	err := c.call(
+		ctx,
 		c.apiKey,
 		c.rlHttpClient,
 		http.MethodGet,
@@ -57,8 +70,14 @@ func (c *Client) List() ([]onfleet.Team, error) {
 
 // Reference https://docs.onfleet.com/reference/create-team
 func (c *Client) Create(params onfleet.TeamCreateParams) (onfleet.Team, error) {
+	return c.CreateCtx(context.Background(), params)
+}
+
+// Reference https://docs.onfleet.com/reference/create-team
+func (c *Client) CreateCtx(ctx context.Context, params onfleet.TeamCreateParams) (onfleet.Team, error) {
 	team := onfleet.Team{}
 	err := c.call(
+		ctx,
 		c.apiKey,
 		c.rlHttpClient,
 		http.MethodPost,
@@ -73,8 +92,14 @@ func (c *Client) Create(params onfleet.TeamCreateParams) (onfleet.Team, error) {
 
 // Reference https://docs.onfleet.com/reference/update-team
 func (c *Client) Update(teamId string, params onfleet.TeamUpdateParams) (onfleet.Team, error) {
+	return c.UpdateCtx(context.Background(), teamId, params)
+}
+
+// Reference https://docs.onfleet.com/reference/update-team
+func (c *Client) UpdateCtx(ctx context.Context, teamId string, params onfleet.TeamUpdateParams) (onfleet.Team, error) {
 	team := onfleet.Team{}
 	err := c.call(
+		ctx,
 		c.apiKey,
 		c.rlHttpClient,
 		http.MethodPut,
@@ -89,7 +114,13 @@ func (c *Client) Update(teamId string, params onfleet.TeamUpdateParams) (onfleet
 
 // Reference https://docs.onfleet.com/reference/delete-team
 func (c *Client) Delete(teamId string) error {
+	return c.DeleteCtx(context.Background(), teamId)
+}
+
+// Reference https://docs.onfleet.com/reference/delete-team
+func (c *Client) DeleteCtx(ctx context.Context, teamId string) error {
 	err := c.call(
+		ctx,
 		c.apiKey,
 		c.rlHttpClient,
 		http.MethodDelete,
@@ -104,8 +135,14 @@ func (c *Client) Delete(teamId string) error {
 
 // Reference https://docs.onfleet.com/reference/team-auto-dispatch
 func (c *Client) AutoDispatch(teamId string, params *onfleet.TeamAutoDispatchParams) (onfleet.TeamAutoDispatch, error) {
+	return c.AutoDispatchCtx(context.Background(), teamId, params)
+}
+
+// Reference https://docs.onfleet.com/reference/team-auto-dispatch
+func (c *Client) AutoDispatchCtx(ctx context.Context, teamId string, params *onfleet.TeamAutoDispatchParams) (onfleet.TeamAutoDispatch, error) {
 	autoDispatch := onfleet.TeamAutoDispatch{}
 	err := c.call(
+		ctx,
 		c.apiKey,
 		c.rlHttpClient,
 		http.MethodPost,
@@ -120,8 +157,14 @@ func (c *Client) AutoDispatch(teamId string, params *onfleet.TeamAutoDispatchPar
 
 // Reference https://docs.onfleet.com/reference/delivery-estimate
 func (c *Client) GetWorkerEta(teamId string, params onfleet.TeamWorkerEtaQueryParams) (onfleet.TeamWorkerEta, error) {
+	return c.GetWorkerEtaCtx(context.Background(), teamId, params)
+}
+
+// Reference https://docs.onfleet.com/reference/delivery-estimate
+func (c *Client) GetWorkerEtaCtx(ctx context.Context, teamId string, params onfleet.TeamWorkerEtaQueryParams) (onfleet.TeamWorkerEta, error) {
 	teamWorkerEta := onfleet.TeamWorkerEta{}
 	err := c.call(
+		ctx,
 		c.apiKey,
 		c.rlHttpClient,
 		http.MethodGet,
@@ -136,8 +179,14 @@ func (c *Client) GetWorkerEta(teamId string, params onfleet.TeamWorkerEtaQueryPa
 
 // Reference https://docs.onfleet.com/reference/list-tasks-in-team
 func (c *Client) ListTasks(teamId string, params *onfleet.TeamTasksListQueryParams) (onfleet.TeamTasks, error) {
+	return c.ListTasksCtx(context.Background(), teamId, params)
+}
+
+// Reference https://docs.onfleet.com/reference/list-tasks-in-team
+func (c *Client) ListTasksCtx(ctx context.Context, teamId string, params *onfleet.TeamTasksListQueryParams) (onfleet.TeamTasks, error) {
 	teamTasks := onfleet.TeamTasks{}
 	err := c.call(
+		ctx,
 		c.apiKey,
 		c.rlHttpClient,
 		http.MethodGet,
@@ -149,3 +198,20 @@ func (c *Client) ListTasks(teamId string, params *onfleet.TeamTasksListQueryPara
 	)
 	return teamTasks, err
 }
+
+// AllTasks iterates over every task of the team matching params, following LastId.
+// params may be nil.
+// opts.PageSize is ignored, the API has a fixed page size.
+//
+// Reference https://docs.onfleet.com/reference/list-tasks-in-team
+func (c *Client) AllTasks(ctx context.Context, teamId string, params *onfleet.TeamTasksListQueryParams, opts *onfleet.IteratorOptions) *onfleet.Iterator[onfleet.Task] {
+	query := onfleet.TeamTasksListQueryParams{}
+	if params != nil {
+		query = *params
+	}
+	return onfleet.NewIterator(ctx, query.LastId, func(ctx context.Context, lastId string) ([]onfleet.Task, string, error) {
+		query.LastId = lastId
+		teamTasks, err := c.ListTasksCtx(ctx, teamId, &query)
+		return teamTasks.Tasks, teamTasks.LastId, err
+	}, opts)
+}
@@ -1,6 +1,7 @@
 package team
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -310,4 +311,41 @@ func TestClient_ErrorScenarios(t *testing.T) {
 			assert.Error(t, err)
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestClient_AllTasks(t *testing.T) {
+	second := testingutil.GetSampleTask()
+	second.ID = "task_456"
+
+	caller := &testingutil.PaginatedCaller{
+		Pages: map[string]interface{}{
+			"":         onfleet.TeamTasks{LastId: "task_123", Tasks: []onfleet.Task{testingutil.GetSampleTask()}},
+			"task_123": onfleet.TeamTasks{Tasks: []onfleet.Task{second}},
+		},
+	}
+	client := Plug("test_api_key", nil, "https://api.example.com/teams", caller.Call)
+
+	tasks, err := client.AllTasks(context.Background(), "team_123", nil, nil).Collect()
+
+	assert.NoError(t, err)
+	assert.Len(t, tasks, 2)
+	assert.Equal(t, "task_456", tasks[1].ID)
+	assert.Equal(t, []string{"", "task_123"}, caller.LastIds)
+}
+
+func TestClient_AllTasks_ResumeFromLastId(t *testing.T) {
+	caller := &testingutil.PaginatedCaller{
+		Pages: map[string]interface{}{
+			"task_123": onfleet.TeamTasks{Tasks: []onfleet.Task{testingutil.GetSampleTask()}},
+		},
+	}
+	client := Plug("test_api_key", nil, "https://api.example.com/teams", caller.Call)
+
+	params := &onfleet.TeamTasksListQueryParams{LastId: "task_123", IsPickupTask: "true"}
+	tasks, err := client.AllTasks(context.Background(), "team_123", params, nil).Collect()
+
+	assert.NoError(t, err)
+	assert.Len(t, tasks, 1)
+	assert.Equal(t, []string{"task_123"}, caller.LastIds)
+	assert.Equal(t, "task_123", params.LastId)
+}
@@ -1,7 +1,8 @@
 package webhook
 
 import (
-    "net/http"
+	"context"
+	"net/http"
 
 	"github.com/onfleet/gonfleet"
 	"github.com/onfleet/gonfleet/netwrk"
@@ -25,8 +26,14 @@ func Plug(apiKey string, rlHttpClient *netwrk.RlHttpClient, url string, call net
 
 // Reference https://docs.onfleet.com/reference/list-webhooks
 func (c *Client) List() ([]onfleet.Webhook, error) {
+	return c.ListCtx(context.Background())
+}
+
+// Reference https://docs.onfleet.com/reference/list-webhooks
+func (c *Client) ListCtx(ctx context.Context) ([]onfleet.Webhook, error) {
 	webhooks := []onfleet.Webhook{}
 	err := c.call(
+		ctx,
 		c.apiKey,
 		c.rlHttpClient,
 		http.MethodGet,
@@ -41,8 +48,14 @@ func (c *Client) List() ([]onfleet.Webhook, error) {
 
 // Reference https://docs.onfleet.com/reference/create-webhook
 func (c *Client) Create(params onfleet.WebhookCreateParams) (onfleet.Webhook, error) {
+	return c.CreateCtx(context.Background(), params)
+}
+
+// Reference https://docs.onfleet.com/reference/create-webhook
+func (c *Client) CreateCtx(ctx context.Context, params onfleet.WebhookCreateParams) (onfleet.Webhook, error) {
 	webhook := onfleet.Webhook{}
 	err := c.call(
+		ctx,
 		c.apiKey,
 		c.rlHttpClient,
 		http.MethodPost,
@@ -55,9 +68,70 @@ func (c *Client) Create(params onfleet.WebhookCreateParams) (onfleet.Webhook, er
 	return webhook, err
 }
 
+// Reference https://docs.onfleet.com/reference/webhooks
+func (c *Client) Get(webhookId string) (onfleet.Webhook, error) {
+	return c.GetCtx(context.Background(), webhookId)
+}
+
+// Reference https://docs.onfleet.com/reference/webhooks
+func (c *Client) GetCtx(ctx context.Context, webhookId string) (onfleet.Webhook, error) {
+	webhook := onfleet.Webhook{}
+	err := c.call(
+		ctx,
+		c.apiKey,
+		c.rlHttpClient,
+		http.MethodGet,
+		c.url,
+		[]string{webhookId},
+		nil,
+		nil,
+		&webhook,
+	)
+	return webhook, err
+}
+
+// Reference https://docs.onfleet.com/reference/webhooks
+func (c *Client) Update(webhookId string, params onfleet.WebhookUpdateParams) (onfleet.Webhook, error) {
+	return c.UpdateCtx(context.Background(), webhookId, params)
+}
+
+// Reference https://docs.onfleet.com/reference/webhooks
+func (c *Client) UpdateCtx(ctx context.Context, webhookId string, params onfleet.WebhookUpdateParams) (onfleet.Webhook, error) {
+	webhook := onfleet.Webhook{}
+	err := c.call(
+		ctx,
+		c.apiKey,
+		c.rlHttpClient,
+		http.MethodPut,
+		c.url,
+		[]string{webhookId},
+		nil,
+		params,
+		&webhook,
+	)
+	return webhook, err
+}
+
+// Enable re-enables a webhook disabled after failed deliveries.
+func (c *Client) Enable(webhookId string) (onfleet.Webhook, error) {
+	return c.EnableCtx(context.Background(), webhookId)
+}
+
+// EnableCtx re-enables a webhook disabled after failed deliveries.
+func (c *Client) EnableCtx(ctx context.Context, webhookId string) (onfleet.Webhook, error) {
+	enabled := true
+	return c.UpdateCtx(ctx, webhookId, onfleet.WebhookUpdateParams{IsEnabled: &enabled})
+}
+
 // Reference https://docs.onfleet.com/reference/delete-webhook
 func (c *Client) Delete(webhookId string) error {
+	return c.DeleteCtx(context.Background(), webhookId)
+}
+
+// Reference https://docs.onfleet.com/reference/delete-webhook
+func (c *Client) DeleteCtx(ctx context.Context, webhookId string) error {
 	err := c.call(
+		ctx,
 		c.apiKey,
 		c.rlHttpClient,
 		http.MethodDelete,
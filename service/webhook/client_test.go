@@ -81,80 +81,122 @@ func TestClient_Delete(t *testing.T) {
 	mockClient.AssertRequestMade("DELETE", "/webhooks/webhook_123")
 }
 
+func TestClient_Get(t *testing.T) {
+	mockClient := testingutil.SetupTest(t)
+	defer testingutil.CleanupTest(t, mockClient)
+
+	expectedWebhook := testingutil.GetSampleWebhook()
+	mockClient.AddResponse("/webhooks/webhook_123", testingutil.MockResponse{
+		StatusCode: 200,
+		Body:       expectedWebhook,
+	})
+
+	client := Plug("test_api_key", nil, "https://api.example.com/webhooks", mockClient.MockCaller)
+
+	webhook, err := client.Get("webhook_123")
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedWebhook, webhook)
+	mockClient.AssertRequestMade("GET", "/webhooks/webhook_123")
+}
+
+func TestClient_Update(t *testing.T) {
+	mockClient := testingutil.SetupTest(t)
+	defer testingutil.CleanupTest(t, mockClient)
+
+	expectedWebhook := testingutil.GetSampleWebhook()
+	expectedWebhook.Url = "https://api.example.com/webhook/v2"
+	mockClient.AddResponse("/webhooks/webhook_123", testingutil.MockResponse{
+		StatusCode: 200,
+		Body:       expectedWebhook,
+	})
+
+	client := Plug("test_api_key", nil, "https://api.example.com/webhooks", mockClient.MockCaller)
+
+	webhook, err := client.Update("webhook_123", onfleet.WebhookUpdateParams{
+		Url:       "https://api.example.com/webhook/v2",
+		Threshold: testingutil.GetFloat64Ptr(0),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedWebhook.Url, webhook.Url)
+	mockClient.AssertRequestMade("PUT", "/webhooks/webhook_123")
+}
+
 func TestClient_WebhookTriggerTypes(t *testing.T) {
 	tests := []struct {
 		name        string
-		trigger     int
+		trigger     onfleet.WebhookTrigger
 		description string
 	}{
-		{
-			name:        "task completed",
-			trigger:     0,
-			description: "Task completed trigger",
-		},
-		{
-			name:        "task failed",
-			trigger:     1,
-			description: "Task failed trigger",
-		},
-		{
-			name:        "worker duty",
-			trigger:     2,
-			description: "Worker duty trigger",
-		},
 		{
 			name:        "task started",
-			trigger:     3,
+			trigger:     onfleet.WebhookTriggerTaskStarted,
 			description: "Task started trigger",
 		},
 		{
 			name:        "task eta",
-			trigger:     4,
+			trigger:     onfleet.WebhookTriggerTaskEta,
 			description: "Task ETA trigger",
 		},
 		{
 			name:        "task arrival",
-			trigger:     5,
+			trigger:     onfleet.WebhookTriggerTaskArrival,
 			description: "Task arrival trigger",
 		},
 		{
-			name:        "task assignment",
-			trigger:     6,
-			description: "Task assignment trigger",
+			name:        "task completed",
+			trigger:     onfleet.WebhookTriggerTaskCompleted,
+			description: "Task completed trigger",
 		},
 		{
-			name:        "task unassignment",
-			trigger:     7,
-			description: "Task unassignment trigger",
+			name:        "task failed",
+			trigger:     onfleet.WebhookTriggerTaskFailed,
+			description: "Task failed trigger",
+		},
+		{
+			name:        "worker duty",
+			trigger:     onfleet.WebhookTriggerWorkerDuty,
+			description: "Worker duty trigger",
 		},
 		{
 			name:        "task creation",
-			trigger:     8,
+			trigger:     onfleet.WebhookTriggerTaskCreated,
 			description: "Task creation trigger",
 		},
 		{
 			name:        "task update",
-			trigger:     9,
+			trigger:     onfleet.WebhookTriggerTaskUpdated,
 			description: "Task update trigger",
 		},
 		{
 			name:        "task deletion",
-			trigger:     10,
+			trigger:     onfleet.WebhookTriggerTaskDeleted,
 			description: "Task deletion trigger",
 		},
 		{
-			name:        "sms recipient response missed",
-			trigger:     12,
-			description: "SMS recipient response missed trigger",
+			name:        "task assignment",
+			trigger:     onfleet.WebhookTriggerTaskAssigned,
+			description: "Task assignment trigger",
 		},
 		{
-			name:        "sms recipient response replied",
-			trigger:     13,
-			description: "SMS recipient response replied trigger",
+			name:        "task unassignment",
+			trigger:     onfleet.WebhookTriggerTaskUnassigned,
+			description: "Task unassignment trigger",
+		},
+		{
+			name:        "task delayed",
+			trigger:     onfleet.WebhookTriggerTaskDelayed,
+			description: "Task delayed trigger",
+		},
+		{
+			name:        "sms recipient response missed",
+			trigger:     onfleet.WebhookTriggerSmsRecipientResponseMissed,
+			description: "SMS recipient response missed trigger",
 		},
 		{
 			name:        "auto dispatch completed",
-			trigger:     14,
+			trigger:     onfleet.WebhookTriggerAutoDispatchJobCompleted,
 			description: "Auto dispatch completed trigger",
 		},
 	}
@@ -0,0 +1,105 @@
+package webhook
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/onfleet/gonfleet"
+)
+
+// HealthCheck reports whether a webhook's url is ready to receive deliveries.
+type HealthCheck func(ctx context.Context, webhook onfleet.Webhook) error
+
+// CheckEndpoint is a HealthCheck sending the validation request Onfleet makes
+// when a webhook is created, GET url?check=<token>, and expecting the token
+// echoed back. httpClient may be nil to use http.DefaultClient.
+func CheckEndpoint(httpClient *http.Client) HealthCheck {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return func(ctx context.Context, webhook onfleet.Webhook) error {
+		token := make([]byte, 8)
+		if _, err := rand.Read(token); err != nil {
+			return err
+		}
+		check := hex.EncodeToString(token)
+		u, err := url.Parse(webhook.Url)
+		if err != nil {
+			return err
+		}
+		query := u.Query()
+		query.Set("check", check)
+		u.RawQuery = query.Encode()
+
+		request, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+		if err != nil {
+			return err
+		}
+		response, err := httpClient.Do(request)
+		if err != nil {
+			return err
+		}
+		defer response.Body.Close()
+		body, err := io.ReadAll(io.LimitReader(response.Body, 1024))
+		if err != nil {
+			return err
+		}
+		if response.StatusCode != http.StatusOK {
+			return fmt.Errorf("webhook endpoint responded %d", response.StatusCode)
+		}
+		if strings.TrimSpace(string(body)) != check {
+			return errors.New("webhook endpoint did not echo the check value")
+		}
+		return nil
+	}
+}
+
+// Disabled lists the webhooks Onfleet disabled after failed deliveries,
+// those not enabled with a non zero Count.
+func (c *Client) Disabled(ctx context.Context) ([]onfleet.Webhook, error) {
+	webhooks, err := c.ListCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var disabled []onfleet.Webhook
+	for _, webhook := range webhooks {
+		if !webhook.IsEnabled && webhook.Count > 0 {
+			disabled = append(disabled, webhook)
+		}
+	}
+	return disabled, nil
+}
+
+// ReenableDisabled re-enables the Disabled webhooks whose url passes check.
+// It returns the webhooks re-enabled along with the failures, joined, of
+// those which were not.
+func (c *Client) ReenableDisabled(ctx context.Context, check HealthCheck) ([]onfleet.Webhook, error) {
+	disabled, err := c.Disabled(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		enabled []onfleet.Webhook
+		errs    []error
+	)
+	for _, webhook := range disabled {
+		if err := check(ctx, webhook); err != nil {
+			errs = append(errs, fmt.Errorf("webhook %s health check: %w", webhook.ID, err))
+			continue
+		}
+		updated, err := c.EnableCtx(ctx, webhook.ID)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("enabling webhook %s: %w", webhook.ID, err))
+			continue
+		}
+		enabled = append(enabled, updated)
+	}
+	return enabled, errors.Join(errs...)
+}
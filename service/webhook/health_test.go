@@ -0,0 +1,70 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onfleet/gonfleet"
+)
+
+func TestCheckEndpoint(t *testing.T) {
+	echo := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, r.URL.Query().Get("check"))
+	}))
+	defer echo.Close()
+	broken := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer broken.Close()
+	wrong := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "ok")
+	}))
+	defer wrong.Close()
+
+	check := CheckEndpoint(nil)
+	assert.NoError(t, check(context.Background(), onfleet.Webhook{Url: echo.URL + "/onfleet?team=a"}))
+	assert.ErrorContains(t, check(context.Background(), onfleet.Webhook{Url: broken.URL}), "502")
+	assert.ErrorContains(t, check(context.Background(), onfleet.Webhook{Url: wrong.URL}), "did not echo")
+}
+
+func TestClient_ReenableDisabled(t *testing.T) {
+	fake := &fakeWebhooks{webhooks: []onfleet.Webhook{
+		{ID: "enabled", IsEnabled: true, Count: 3, Url: "https://healthy.example.com"},
+		{ID: "never-fired", IsEnabled: false, Count: 0, Url: "https://healthy.example.com"},
+		{ID: "healthy", IsEnabled: false, Count: 12, Url: "https://healthy.example.com"},
+		{ID: "down", IsEnabled: false, Count: 7, Url: "https://down.example.com"},
+	}}
+	client := Plug("test_api_key", nil, "https://api.example.com/webhooks", fake.call)
+
+	disabled, err := client.Disabled(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"healthy", "down"}, webhookIds(disabled))
+
+	var checked []string
+	enabled, err := client.ReenableDisabled(context.Background(), func(ctx context.Context, webhook onfleet.Webhook) error {
+		checked = append(checked, webhook.ID)
+		if webhook.Url == "https://down.example.com" {
+			return errors.New("connection refused")
+		}
+		return nil
+	})
+
+	assert.EqualError(t, err, "webhook down health check: connection refused")
+	assert.Equal(t, []string{"healthy", "down"}, checked)
+	require.Len(t, enabled, 1)
+	assert.Equal(t, "healthy", enabled[0].ID)
+	assert.True(t, enabled[0].IsEnabled)
+
+	webhook, err := client.Get("down")
+	require.NoError(t, err)
+	assert.False(t, webhook.IsEnabled)
+	assert.Contains(t, fake.calls, "PUT healthy")
+	assert.NotContains(t, fake.calls, "PUT down")
+}
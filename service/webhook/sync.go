@@ -0,0 +1,135 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/onfleet/gonfleet"
+)
+
+// SyncOptions configures Sync.
+type SyncOptions struct {
+	// DryRun returns the plan without creating or deleting any webhook.
+	DryRun bool
+	// NamePrefix, if set, marks the webhooks managed by Sync. Created webhooks
+	// are named NamePrefix + Name and only webhooks whose name starts with
+	// NamePrefix are deleted, others are left alone and reported as Skipped.
+	// Empty manages every webhook of the organization.
+	NamePrefix string
+}
+
+// SyncPlan lists the changes Sync makes, or would make with DryRun.
+type SyncPlan struct {
+	// Create are the desired webhooks missing from the organization.
+	Create []onfleet.WebhookCreateParams
+	// Delete are the existing webhooks not desired, or duplicates.
+	Delete []onfleet.Webhook
+	// Keep are the existing webhooks matching a desired one.
+	Keep []onfleet.Webhook
+	// Skipped are the webhooks not desired but left alone as they do not
+	// carry NamePrefix.
+	Skipped []onfleet.Webhook
+}
+
+// Empty reports whether the plan changes nothing.
+func (p SyncPlan) Empty() bool {
+	return len(p.Create) == 0 && len(p.Delete) == 0
+}
+
+// String renders the plan one webhook per line, e.g. for a dry run.
+func (p SyncPlan) String() string {
+	var b strings.Builder
+	for _, w := range p.Create {
+		fmt.Fprintf(&b, "+ create %s %s threshold=%g name=%q\n", w.Trigger, w.Url, w.Threshold, w.Name)
+	}
+	for _, w := range p.Delete {
+		fmt.Fprintf(&b, "- delete %s %s %s threshold=%g name=%q\n", w.ID, w.Trigger, w.Url, w.Threshold, w.Name)
+	}
+	for _, w := range p.Keep {
+		fmt.Fprintf(&b, "  keep   %s %s %s threshold=%g name=%q\n", w.ID, w.Trigger, w.Url, w.Threshold, w.Name)
+	}
+	for _, w := range p.Skipped {
+		fmt.Fprintf(&b, "  skip   %s %s %s threshold=%g name=%q (not managed)\n", w.ID, w.Trigger, w.Url, w.Threshold, w.Name)
+	}
+	return b.String()
+}
+
+type syncKey struct {
+	url       string
+	trigger   onfleet.WebhookTrigger
+	threshold float64
+}
+
+// Sync reconciles the organization's webhooks with desired. Webhooks are
+// matched by url, trigger and threshold, names are ignored. Missing webhooks
+// are created before stale ones are deleted so deliveries are not interrupted.
+//
+// The plan is returned along with the first error, which stops the sync.
+func (c *Client) Sync(ctx context.Context, desired []onfleet.WebhookCreateParams, opts *SyncOptions) (SyncPlan, error) {
+	var o SyncOptions
+	if opts != nil {
+		o = *opts
+	}
+	existing, err := c.ListCtx(ctx)
+	if err != nil {
+		return SyncPlan{}, err
+	}
+	plan, err := planSync(existing, desired, o.NamePrefix)
+	if err != nil || o.DryRun {
+		return plan, err
+	}
+	for _, params := range plan.Create {
+		if _, err := c.CreateCtx(ctx, params); err != nil {
+			return plan, fmt.Errorf("creating webhook %s %s: %w", params.Trigger, params.Url, err)
+		}
+	}
+	for _, webhook := range plan.Delete {
+		if err := c.DeleteCtx(ctx, webhook.ID); err != nil {
+			return plan, fmt.Errorf("deleting webhook %s: %w", webhook.ID, err)
+		}
+	}
+	return plan, nil
+}
+
+func planSync(existing []onfleet.Webhook, desired []onfleet.WebhookCreateParams, namePrefix string) (SyncPlan, error) {
+	var plan SyncPlan
+	wanted := map[syncKey]bool{}
+	for _, params := range desired {
+		if params.Url == "" {
+			return plan, errors.New("webhook sync: desired webhook without Url")
+		}
+		key := syncKey{params.Url, params.Trigger, params.Threshold}
+		if _, ok := wanted[key]; ok {
+			continue
+		}
+		wanted[key] = false
+		for _, webhook := range existing {
+			if (syncKey{webhook.Url, webhook.Trigger, webhook.Threshold}) == key {
+				wanted[key] = true
+				break
+			}
+		}
+		if !wanted[key] {
+			params.Name = namePrefix + params.Name
+			plan.Create = append(plan.Create, params)
+		}
+	}
+
+	kept := map[syncKey]bool{}
+	for _, webhook := range existing {
+		key := syncKey{webhook.Url, webhook.Trigger, webhook.Threshold}
+		if _, ok := wanted[key]; ok && !kept[key] {
+			kept[key] = true
+			plan.Keep = append(plan.Keep, webhook)
+			continue
+		}
+		if namePrefix != "" && !strings.HasPrefix(webhook.Name, namePrefix) {
+			plan.Skipped = append(plan.Skipped, webhook)
+			continue
+		}
+		plan.Delete = append(plan.Delete, webhook)
+	}
+	return plan, nil
+}
@@ -0,0 +1,194 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onfleet/gonfleet"
+	"github.com/onfleet/gonfleet/netwrk"
+)
+
+// fakeWebhooks is an in memory webhooks endpoint.
+type fakeWebhooks struct {
+	webhooks []onfleet.Webhook
+	calls    []string
+	nextId   int
+	failOn   string
+}
+
+func (f *fakeWebhooks) call(ctx context.Context, apiKey string, rlHttpClient *netwrk.RlHttpClient, method string, baseUrl string, pathSegments []string, queryParams any, body any, v any, additionalHeaders ...[2]string) error {
+	call := method
+	for _, seg := range pathSegments {
+		call += " " + seg
+	}
+	f.calls = append(f.calls, call)
+	if call == f.failOn {
+		return onfleet.RequestError{Code: onfleet.ErrorCodeInternalError, StatusCode: http.StatusInternalServerError}
+	}
+	switch method {
+	case http.MethodGet:
+		if len(pathSegments) == 0 {
+			*v.(*[]onfleet.Webhook) = append([]onfleet.Webhook{}, f.webhooks...)
+			return nil
+		}
+		for _, webhook := range f.webhooks {
+			if webhook.ID == pathSegments[0] {
+				*v.(*onfleet.Webhook) = webhook
+				return nil
+			}
+		}
+		return onfleet.RequestError{Code: onfleet.ErrorCodeResourceNotFound, StatusCode: http.StatusNotFound}
+	case http.MethodPut:
+		params := body.(onfleet.WebhookUpdateParams)
+		for i, webhook := range f.webhooks {
+			if webhook.ID == pathSegments[0] {
+				if params.IsEnabled != nil {
+					webhook.IsEnabled = *params.IsEnabled
+				}
+				if params.Name != "" {
+					webhook.Name = params.Name
+				}
+				if params.Threshold != nil {
+					webhook.Threshold = *params.Threshold
+				}
+				if params.Url != "" {
+					webhook.Url = params.Url
+				}
+				f.webhooks[i] = webhook
+				*v.(*onfleet.Webhook) = webhook
+				return nil
+			}
+		}
+		return onfleet.RequestError{Code: onfleet.ErrorCodeResourceNotFound, StatusCode: http.StatusNotFound}
+	case http.MethodPost:
+		params := body.(onfleet.WebhookCreateParams)
+		f.nextId++
+		webhook := onfleet.Webhook{
+			ID:        fmt.Sprintf("created_%d", f.nextId),
+			IsEnabled: true,
+			Name:      params.Name,
+			Threshold: params.Threshold,
+			Trigger:   params.Trigger,
+			Url:       params.Url,
+		}
+		f.webhooks = append(f.webhooks, webhook)
+		*v.(*onfleet.Webhook) = webhook
+	case http.MethodDelete:
+		for i, webhook := range f.webhooks {
+			if webhook.ID == pathSegments[0] {
+				f.webhooks = append(f.webhooks[:i], f.webhooks[i+1:]...)
+				return nil
+			}
+		}
+		return onfleet.RequestError{Code: onfleet.ErrorCodeResourceNotFound, StatusCode: http.StatusNotFound}
+	}
+	return nil
+}
+
+const hookUrl = "https://api.example.com/webhook/onfleet"
+
+func syncFixture() *fakeWebhooks {
+	return &fakeWebhooks{webhooks: []onfleet.Webhook{
+		{ID: "keep", Name: "svc: completed", Trigger: onfleet.WebhookTriggerTaskCompleted, Url: hookUrl},
+		{ID: "duplicate", Name: "svc: completed again", Trigger: onfleet.WebhookTriggerTaskCompleted, Url: hookUrl},
+		{ID: "stale", Name: "svc: failed", Trigger: onfleet.WebhookTriggerTaskFailed, Url: hookUrl},
+		{ID: "foreign", Name: "someone else", Trigger: onfleet.WebhookTriggerTaskEta, Url: "https://other.example.com"},
+		{ID: "threshold", Name: "svc: eta", Trigger: onfleet.WebhookTriggerTaskEta, Url: hookUrl, Threshold: 300},
+	}}
+}
+
+var desiredHooks = []onfleet.WebhookCreateParams{
+	{Name: "completed", Trigger: onfleet.WebhookTriggerTaskCompleted, Url: hookUrl},
+	{Name: "eta", Trigger: onfleet.WebhookTriggerTaskEta, Url: hookUrl, Threshold: 600},
+	{Name: "started", Trigger: onfleet.WebhookTriggerTaskStarted, Url: hookUrl},
+	{Name: "started twice", Trigger: onfleet.WebhookTriggerTaskStarted, Url: hookUrl},
+}
+
+func webhookIds(webhooks []onfleet.Webhook) []string {
+	var ids []string
+	for _, webhook := range webhooks {
+		ids = append(ids, webhook.ID)
+	}
+	return ids
+}
+
+func TestClient_Sync(t *testing.T) {
+	fake := syncFixture()
+	client := Plug("test_api_key", nil, "https://api.example.com/webhooks", fake.call)
+
+	plan, err := client.Sync(context.Background(), desiredHooks, nil)
+
+	require.NoError(t, err)
+	require.Len(t, plan.Create, 2)
+	assert.Equal(t, onfleet.WebhookTriggerTaskEta, plan.Create[0].Trigger)
+	assert.Equal(t, "started", plan.Create[1].Name)
+	assert.Equal(t, []string{"keep"}, webhookIds(plan.Keep))
+	assert.Equal(t, []string{"duplicate", "stale", "foreign", "threshold"}, webhookIds(plan.Delete))
+	assert.Empty(t, plan.Skipped)
+	// creations happen before deletions
+	assert.Equal(t, []string{"GET", "POST", "POST", "DELETE duplicate", "DELETE stale", "DELETE foreign", "DELETE threshold"}, fake.calls)
+	assert.Equal(t, []string{"keep", "created_1", "created_2"}, webhookIds(fake.webhooks))
+
+	// converged
+	fake.calls = nil
+	plan, err = client.Sync(context.Background(), desiredHooks, nil)
+	require.NoError(t, err)
+	assert.True(t, plan.Empty())
+	assert.Equal(t, []string{"GET"}, fake.calls)
+}
+
+func TestClient_Sync_DryRun(t *testing.T) {
+	fake := syncFixture()
+	client := Plug("test_api_key", nil, "https://api.example.com/webhooks", fake.call)
+
+	plan, err := client.Sync(context.Background(), desiredHooks, &SyncOptions{DryRun: true, NamePrefix: "svc: "})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"GET"}, fake.calls)
+	assert.Len(t, fake.webhooks, 5)
+	require.Len(t, plan.Create, 2)
+	assert.Equal(t, "svc: eta", plan.Create[0].Name)
+	assert.Equal(t, []string{"duplicate", "stale", "threshold"}, webhookIds(plan.Delete))
+	assert.Equal(t, []string{"foreign"}, webhookIds(plan.Skipped))
+	assert.Contains(t, plan.String(), `+ create taskEta `+hookUrl+` threshold=600 name="svc: eta"`)
+	assert.Contains(t, plan.String(), `- delete stale taskFailed `+hookUrl)
+	assert.Contains(t, plan.String(), `skip   foreign taskEta https://other.example.com threshold=0 name="someone else" (not managed)`)
+}
+
+func TestClient_Sync_NamePrefix(t *testing.T) {
+	fake := syncFixture()
+	client := Plug("test_api_key", nil, "https://api.example.com/webhooks", fake.call)
+
+	_, err := client.Sync(context.Background(), nil, &SyncOptions{NamePrefix: "svc: "})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"foreign"}, webhookIds(fake.webhooks))
+}
+
+func TestClient_Sync_Errors(t *testing.T) {
+	fake := syncFixture()
+	client := Plug("test_api_key", nil, "https://api.example.com/webhooks", fake.call)
+
+	_, err := client.Sync(context.Background(), []onfleet.WebhookCreateParams{{Trigger: onfleet.WebhookTriggerTaskStarted}}, nil)
+	assert.ErrorContains(t, err, "without Url")
+	assert.Equal(t, []string{"GET"}, fake.calls)
+
+	fake.calls = nil
+	fake.failOn = "DELETE stale"
+	plan, err := client.Sync(context.Background(), desiredHooks, nil)
+	assert.True(t, onfleet.IsServerError(err))
+	assert.ErrorContains(t, err, "deleting webhook stale")
+	assert.Len(t, plan.Delete, 4)
+	assert.Equal(t, []string{"GET", "POST", "POST", "DELETE duplicate", "DELETE stale"}, fake.calls)
+
+	fake.failOn = "GET"
+	_, err = client.Sync(context.Background(), desiredHooks, nil)
+	var reqError onfleet.RequestError
+	assert.True(t, errors.As(err, &reqError))
+}
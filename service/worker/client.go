@@ -1,6 +1,7 @@
 package worker
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/onfleet/gonfleet"
@@ -25,8 +26,14 @@ func Plug(apiKey string, rlHttpClient *netwrk.RlHttpClient, url string, call net
 
 // Reference https://docs.onfleet.com/reference/get-single-worker
 func (c *Client) Get(workerId string) (onfleet.Worker, error) {
+	return c.GetCtx(context.Background(), workerId)
+}
+
+// Reference https://docs.onfleet.com/reference/get-single-worker
+func (c *Client) GetCtx(ctx context.Context, workerId string) (onfleet.Worker, error) {
 	worker := onfleet.Worker{}
 	err := c.call(
+		ctx,
 		c.apiKey,
 		c.rlHttpClient,
 		http.MethodGet,
@@ -41,8 +48,14 @@ func (c *Client) Get(workerId string) (onfleet.Worker, error) {
 
 // Reference https://docs.onfleet.com/reference/get-single-worker
 func (c *Client) GetWithQuery(workerId string, params onfleet.WorkerGetQueryParams) (map[string]any, error) {
+	return c.GetWithQueryCtx(context.Background(), workerId, params)
+}
+
+// Reference https://docs.onfleet.com/reference/get-single-worker
+func (c *Client) GetWithQueryCtx(ctx context.Context, workerId string, params onfleet.WorkerGetQueryParams) (map[string]any, error) {
 	worker := map[string]any{}
 	err := c.call(
+		ctx,
 		c.apiKey,
 		c.rlHttpClient,
 		http.MethodGet,
@@ -57,8 +70,14 @@ func (c *Client) GetWithQuery(workerId string, params onfleet.WorkerGetQueryPara
 
 // Reference https://docs.onfleet.com/reference/list-workers
 func (c *Client) List() ([]onfleet.Worker, error) {
+	return c.ListCtx(context.Background())
+}
+
+// Reference https://docs.onfleet.com/reference/list-workers
+func (c *Client) ListCtx(ctx context.Context) ([]onfleet.Worker, error) {
 	workers := []onfleet.Worker{}
 	err := c.call(
+		ctx,
 		c.apiKey,
 		c.rlHttpClient,
 		http.MethodGet,
@@ -73,8 +92,14 @@ func (c *Client) List() ([]onfleet.Worker, error) {
 
 // Reference https://docs.onfleet.com/reference/querying-by-metadata
 func (c *Client) ListWithMetadataQuery(metadata []onfleet.Metadata) ([]onfleet.Worker, error) {
+	return c.ListWithMetadataQueryCtx(context.Background(), metadata)
+}
+
+// Reference https://docs.onfleet.com/reference/querying-by-metadata
+func (c *Client) ListWithMetadataQueryCtx(ctx context.Context, metadata []onfleet.Metadata) ([]onfleet.Worker, error) {
 	workers := []onfleet.Worker{}
 	err := c.call(
+		ctx,
 		c.apiKey,
 		c.rlHttpClient,
 		http.MethodPost,
@@ -89,8 +114,14 @@ func (c *Client) ListWithMetadataQuery(metadata []onfleet.Metadata) ([]onfleet.W
 
 // Reference // Reference https://docs.onfleet.com/reference/list-workers
 func (c *Client) ListWithQuery(params onfleet.WorkerListQueryParams) ([]map[string]any, error) {
+	return c.ListWithQueryCtx(context.Background(), params)
+}
+
+// Reference // Reference https://docs.onfleet.com/reference/list-workers
+func (c *Client) ListWithQueryCtx(ctx context.Context, params onfleet.WorkerListQueryParams) ([]map[string]any, error) {
 	workers := []map[string]any{}
 	err := c.call(
+		ctx,
 		c.apiKey,
 		c.rlHttpClient,
 		http.MethodGet,
@@ -105,8 +136,14 @@ func (c *Client) ListWithQuery(params onfleet.WorkerListQueryParams) ([]map[stri
 
 // Reference https://docs.onfleet.com/reference/get-workers-schedule
 func (c *Client) GetSchedule(workerId string) (onfleet.WorkerScheduleEntries, error) {
+	return c.GetScheduleCtx(context.Background(), workerId)
+}
+
+// Reference https://docs.onfleet.com/reference/get-workers-schedule
+func (c *Client) GetScheduleCtx(ctx context.Context, workerId string) (onfleet.WorkerScheduleEntries, error) {
 	scheduleEntries := onfleet.WorkerScheduleEntries{}
 	err := c.call(
+		ctx,
 		c.apiKey,
 		c.rlHttpClient,
 		http.MethodGet,
@@ -121,8 +158,14 @@ func (c *Client) GetSchedule(workerId string) (onfleet.WorkerScheduleEntries, er
 
 // Reference https://docs.onfleet.com/reference/get-workers-by-location
 func (c *Client) ListWorkersByLocation(params onfleet.WorkersByLocationListQueryParams) (onfleet.WorkersByLocation, error) {
+	return c.ListWorkersByLocationCtx(context.Background(), params)
+}
+
+// Reference https://docs.onfleet.com/reference/get-workers-by-location
+func (c *Client) ListWorkersByLocationCtx(ctx context.Context, params onfleet.WorkersByLocationListQueryParams) (onfleet.WorkersByLocation, error) {
 	workersByLocation := onfleet.WorkersByLocation{}
 	err := c.call(
+		ctx,
 		c.apiKey,
 		c.rlHttpClient,
 		http.MethodGet,
@@ -137,8 +180,14 @@ func (c *Client) ListWorkersByLocation(params onfleet.WorkersByLocationListQuery
 
 // Reference https://docs.onfleet.com/reference/set-workers-schedule
 func (c *Client) SetSchedule(workerId string, entries onfleet.WorkerScheduleEntries) (onfleet.WorkerScheduleEntries, error) {
+	return c.SetScheduleCtx(context.Background(), workerId, entries)
+}
+
+// Reference https://docs.onfleet.com/reference/set-workers-schedule
+func (c *Client) SetScheduleCtx(ctx context.Context, workerId string, entries onfleet.WorkerScheduleEntries) (onfleet.WorkerScheduleEntries, error) {
 	scheduleEntries := onfleet.WorkerScheduleEntries{}
 	err := c.call(
+		ctx,
 		c.apiKey,
 		c.rlHttpClient,
 		http.MethodPost,
@@ -153,8 +202,14 @@ func (c *Client) SetSchedule(workerId string, entries onfleet.WorkerScheduleEntr
 
 // Reference https://docs.onfleet.com/reference/list-workers-assigned-tasks
 func (c *Client) ListTasks(workerId string, params *onfleet.WorkerTasksListQueryParams) (onfleet.WorkerTasks, error) {
+	return c.ListTasksCtx(context.Background(), workerId, params)
+}
+
+// Reference https://docs.onfleet.com/reference/list-workers-assigned-tasks
+func (c *Client) ListTasksCtx(ctx context.Context, workerId string, params *onfleet.WorkerTasksListQueryParams) (onfleet.WorkerTasks, error) {
 	workerTasks := onfleet.WorkerTasks{}
 	err := c.call(
+		ctx,
 		c.apiKey,
 		c.rlHttpClient,
 		http.MethodGet,
@@ -169,8 +224,14 @@ func (c *Client) ListTasks(workerId string, params *onfleet.WorkerTasksListQuery
 
 // Reference https://docs.onfleet.com/reference/create-worker
 func (c *Client) Create(params onfleet.WorkerCreateParams) (onfleet.Worker, error) {
+	return c.CreateCtx(context.Background(), params)
+}
+
+// Reference https://docs.onfleet.com/reference/create-worker
+func (c *Client) CreateCtx(ctx context.Context, params onfleet.WorkerCreateParams) (onfleet.Worker, error) {
 	worker := onfleet.Worker{}
 	err := c.call(
+		ctx,
 		c.apiKey,
 		c.rlHttpClient,
 		http.MethodPost,
@@ -185,8 +246,14 @@ func (c *Client) Create(params onfleet.WorkerCreateParams) (onfleet.Worker, erro
 
 // Reference https://docs.onfleet.com/reference/update-worker
 func (c *Client) Update(workerId string, params onfleet.WorkerUpdateParams) (onfleet.Worker, error) {
+	return c.UpdateCtx(context.Background(), workerId, params)
+}
+
+// Reference https://docs.onfleet.com/reference/update-worker
+func (c *Client) UpdateCtx(ctx context.Context, workerId string, params onfleet.WorkerUpdateParams) (onfleet.Worker, error) {
 	worker := onfleet.Worker{}
 	err := c.call(
+		ctx,
 		c.apiKey,
 		c.rlHttpClient,
 		http.MethodPut,
@@ -201,7 +268,13 @@ func (c *Client) Update(workerId string, params onfleet.WorkerUpdateParams) (onf
 
 // Reference https://docs.onfleet.com/reference/delete-worker
 func (c *Client) Delete(workerId string) error {
+	return c.DeleteCtx(context.Background(), workerId)
+}
+
+// Reference https://docs.onfleet.com/reference/delete-worker
+func (c *Client) DeleteCtx(ctx context.Context, workerId string) error {
 	err := c.call(
+		ctx,
 		c.apiKey,
 		c.rlHttpClient,
 		http.MethodDelete,
@@ -213,3 +286,20 @@ func (c *Client) Delete(workerId string) error {
 	)
 	return err
 }
+
+// AllTasks iterates over every task of the worker matching params, following LastId.
+// params may be nil.
+// opts.PageSize is ignored, the API has a fixed page size.
+//
+// Reference https://docs.onfleet.com/reference/list-workers-assigned-tasks
+func (c *Client) AllTasks(ctx context.Context, workerId string, params *onfleet.WorkerTasksListQueryParams, opts *onfleet.IteratorOptions) *onfleet.Iterator[onfleet.Task] {
+	query := onfleet.WorkerTasksListQueryParams{}
+	if params != nil {
+		query = *params
+	}
+	return onfleet.NewIterator(ctx, query.LastId, func(ctx context.Context, lastId string) ([]onfleet.Task, string, error) {
+		query.LastId = lastId
+		workerTasks, err := c.ListTasksCtx(ctx, workerId, &query)
+		return workerTasks.Tasks, workerTasks.LastId, err
+	}, opts)
+}
@@ -1,5 +1,7 @@
 package onfleet
 
+import "time"
+
 type Task struct {
 	AdditionalQuantities     TaskAdditionalQuantities `json:"additionalQuantities"`
 	Appearance               TaskAppearance           `json:"appearance"`
@@ -127,6 +129,8 @@ type TaskCapturedBarcode struct {
 	WasRequested bool                `json:"wasRequested"`
 }
 
+// TaskParams are used to create and update tasks. Only the fields set are
+// sent, so an update leaves nil and empty fields unchanged.
 type TaskParams struct {
 	Appearance     *TaskAppearanceParam `json:"appearance,omitempty"`
 	AutoAssign     *TaskAutoAssignParam `json:"autoAssign,omitempty"`
@@ -136,23 +140,25 @@ type TaskParams struct {
 	Container      *TaskContainer       `json:"container,omitempty"`
 	CustomFields   []CustomFieldParams  `json:"customFields,omitempty"`
 	Dependencies   []string             `json:"dependencies,omitempty"`
-	// Destination can string destination id or destination object onfleet.DestinationCreateParams
+	// Destination is a TaskDestination built with onfleet.DestinationByID or onfleet.NewDestination.
+	// A string destination id or onfleet.DestinationCreateParams are also accepted.
 	Destination    any        `json:"destination,omitempty"`
 	Executor       string     `json:"executor,omitempty"`
 	Merchant       string     `json:"merchant,omitempty"`
 	Metadata       []Metadata `json:"metadata,omitempty"`
 	Notes          string     `json:"notes,omitempty"`
-	PickupTask     bool       `json:"pickupTask"`
-	Quantity       float64    `json:"quantity,omitempty"`
+	PickupTask     *bool      `json:"pickupTask,omitempty"`
+	Quantity       *float64   `json:"quantity,omitempty"`
 	RecipientName  string     `json:"recipientName,omitempty"`
 	RecipientNotes string     `json:"recipientNotes,omitempty"`
-	// Recipients can be slice of string recipient ids or recipient objects []onfleet.RecipientCreateParams
+	// Recipients is a TaskRecipients built with onfleet.RecipientsByIDs or onfleet.NewRecipients.
+	// A slice of string recipient ids or []onfleet.RecipientCreateParams are also accepted.
 	Recipients                    any                              `json:"recipients,omitempty"`
-	RecipientSkipSmsNotifications bool                             `json:"recipientSkipSMSNotifications,omitempty"`
+	RecipientSkipSmsNotifications *bool                            `json:"recipientSkipSMSNotifications,omitempty"`
 	Requirements                  *TaskCompletionRequirementsParam `json:"requirements,omitempty"`
-	ScanOnlyRequiredBarcodes      bool                             `json:"scanOnlyRequiredBarcodes,omitempty"`
-	ServiceTime                   float64                          `json:"serviceTime,omitempty"`
-	UseMerchantForProxy           bool                             `json:"useMerchantForProxy,omitempty"`
+	ScanOnlyRequiredBarcodes      *bool                            `json:"scanOnlyRequiredBarcodes,omitempty"`
+	ServiceTime                   *float64                         `json:"serviceTime,omitempty"`
+	UseMerchantForProxy           *bool                            `json:"useMerchantForProxy,omitempty"`
 }
 
 type TaskAutoAssignMode string
@@ -258,14 +264,16 @@ type TaskCloneParams struct {
 type TaskCloneOverridesParam struct {
 	CompleteAfter  int64 `json:"completeAfter,omitempty"`
 	CompleteBefore int64 `json:"completeBefore,omitempty"`
-	// Destination can string destination id or destination object onfleet.DestinationCreateParams
+	// Destination is a TaskDestination built with onfleet.DestinationByID or onfleet.NewDestination.
+	// A string destination id or onfleet.DestinationCreateParams are also accepted.
 	Destination any        `json:"destination,omitempty"`
 	Metadata    []Metadata `json:"metadata,omitempty"`
 	Notes       string     `json:"notes,omitempty"`
-	PickupTask  bool       `json:"pickupTask"`
-	// Recipients can be slice of string recipient ids or recipient objects []onfleet.RecipientCreateParams
-	Recipients  any     `json:"recipients,omitempty"`
-	ServiceTime float64 `json:"serviceTime,omitempty"`
+	PickupTask  *bool      `json:"pickupTask,omitempty"`
+	// Recipients is a TaskRecipients built with onfleet.RecipientsByIDs or onfleet.NewRecipients.
+	// A slice of string recipient ids or []onfleet.RecipientCreateParams are also accepted.
+	Recipients  any      `json:"recipients,omitempty"`
+	ServiceTime *float64 `json:"serviceTime,omitempty"`
 }
 
 type TaskListQueryParams struct {
@@ -279,3 +287,16 @@ type TaskListQueryParams struct {
 	CompleteAfterAfter   int64    `json:"completeAfterAfter,omitempty,string"`
 	Dependencies         []string `json:"dependencies,omitempty"`
 }
+
+// SetRange sets From and To. A zero to leaves the range open ended.
+func (p *TaskListQueryParams) SetRange(from, to time.Time) {
+	p.From = int64(FromTime(from))
+	p.To = int64(FromTime(to))
+}
+
+// SetCompleteRange sets CompleteAfterAfter and CompleteBeforeBefore,
+// zero times are left unset.
+func (p *TaskListQueryParams) SetCompleteRange(after, before time.Time) {
+	p.CompleteAfterAfter = int64(FromTime(after))
+	p.CompleteBeforeBefore = int64(FromTime(before))
+}
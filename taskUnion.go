@@ -0,0 +1,192 @@
+package onfleet
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// TaskDestination is the destination of a task, either an existing
+// destination id or a destination to create along with the task.
+// Build it with DestinationByID or NewDestination.
+type TaskDestination struct {
+	id     string
+	params *DestinationCreateParams
+}
+
+// DestinationByID refers to an existing destination.
+func DestinationByID(id string) TaskDestination {
+	return TaskDestination{id: id}
+}
+
+// NewDestination creates the destination along with the task.
+func NewDestination(params DestinationCreateParams) TaskDestination {
+	return TaskDestination{params: &params}
+}
+
+// ID returns the destination id, if the destination refers to one.
+func (d TaskDestination) ID() (string, bool) {
+	return d.id, d.params == nil && d.id != ""
+}
+
+// Params returns the destination to create, if any.
+func (d TaskDestination) Params() (DestinationCreateParams, bool) {
+	if d.params == nil {
+		return DestinationCreateParams{}, false
+	}
+	return *d.params, true
+}
+
+// IsZero reports whether the destination was not set.
+func (d TaskDestination) IsZero() bool {
+	return d.id == "" && d.params == nil
+}
+
+func (d TaskDestination) MarshalJSON() ([]byte, error) {
+	if d.params != nil {
+		return json.Marshal(d.params)
+	}
+	if d.id != "" {
+		return json.Marshal(d.id)
+	}
+	return []byte("null"), nil
+}
+
+func (d *TaskDestination) UnmarshalJSON(data []byte) error {
+	*d = TaskDestination{}
+	data = bytes.TrimSpace(data)
+	switch {
+	case bytes.Equal(data, []byte("null")):
+		return nil
+	case len(data) > 0 && data[0] == '"':
+		return json.Unmarshal(data, &d.id)
+	}
+	d.params = &DestinationCreateParams{}
+	return json.Unmarshal(data, d.params)
+}
+
+// ParseTaskDestination converts the values accepted by TaskParams.Destination,
+// a TaskDestination, a destination id string, DestinationCreateParams or the
+// map decoded from json, to a TaskDestination.
+func ParseTaskDestination(v any) (TaskDestination, error) {
+	switch d := v.(type) {
+	case nil:
+		return TaskDestination{}, nil
+	case TaskDestination:
+		return d, nil
+	case *TaskDestination:
+		if d == nil {
+			return TaskDestination{}, nil
+		}
+		return *d, nil
+	case string:
+		return DestinationByID(d), nil
+	case DestinationCreateParams:
+		return NewDestination(d), nil
+	case *DestinationCreateParams:
+		if d == nil {
+			return TaskDestination{}, nil
+		}
+		return NewDestination(*d), nil
+	case map[string]any:
+		var destination TaskDestination
+		data, err := json.Marshal(d)
+		if err == nil {
+			err = json.Unmarshal(data, &destination)
+		}
+		return destination, err
+	}
+	return TaskDestination{}, fmt.Errorf("must be a destination id or DestinationCreateParams, got %T", v)
+}
+
+// TaskRecipients are the recipients of a task, either existing recipient ids
+// or recipients to create along with the task.
+// Build them with RecipientsByIDs or NewRecipients.
+type TaskRecipients struct {
+	ids    []string
+	params []RecipientCreateParams
+}
+
+// RecipientsByIDs refers to existing recipients.
+func RecipientsByIDs(ids ...string) TaskRecipients {
+	return TaskRecipients{ids: ids}
+}
+
+// NewRecipients creates the recipients along with the task.
+func NewRecipients(params ...RecipientCreateParams) TaskRecipients {
+	return TaskRecipients{params: params}
+}
+
+// IDs returns the recipient ids, if the recipients refer to existing ones.
+func (r TaskRecipients) IDs() ([]string, bool) {
+	return r.ids, r.ids != nil
+}
+
+// Params returns the recipients to create, if any.
+func (r TaskRecipients) Params() ([]RecipientCreateParams, bool) {
+	return r.params, r.params != nil
+}
+
+// IsZero reports whether the recipients were not set.
+func (r TaskRecipients) IsZero() bool {
+	return r.ids == nil && r.params == nil
+}
+
+func (r TaskRecipients) MarshalJSON() ([]byte, error) {
+	switch {
+	case r.params != nil:
+		return json.Marshal(r.params)
+	case r.ids != nil:
+		return json.Marshal(r.ids)
+	}
+	return []byte("null"), nil
+}
+
+func (r *TaskRecipients) UnmarshalJSON(data []byte) error {
+	*r = TaskRecipients{}
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	var items []json.RawMessage
+	if err := json.Unmarshal(data, &items); err != nil {
+		return err
+	}
+	if len(items) == 0 {
+		r.ids = []string{}
+		return nil
+	}
+	if bytes.HasPrefix(bytes.TrimSpace(items[0]), []byte(`"`)) {
+		return json.Unmarshal(data, &r.ids)
+	}
+	return json.Unmarshal(data, &r.params)
+}
+
+// ParseTaskRecipients converts the values accepted by TaskParams.Recipients,
+// TaskRecipients, []string recipient ids, []RecipientCreateParams or the
+// slice decoded from json, to TaskRecipients.
+func ParseTaskRecipients(v any) (TaskRecipients, error) {
+	switch r := v.(type) {
+	case nil:
+		return TaskRecipients{}, nil
+	case TaskRecipients:
+		return r, nil
+	case *TaskRecipients:
+		if r == nil {
+			return TaskRecipients{}, nil
+		}
+		return *r, nil
+	case []string:
+		return RecipientsByIDs(r...), nil
+	case []RecipientCreateParams:
+		return NewRecipients(r...), nil
+	case []any:
+		var recipients TaskRecipients
+		data, err := json.Marshal(r)
+		if err == nil {
+			err = json.Unmarshal(data, &recipients)
+		}
+		return recipients, err
+	}
+	return TaskRecipients{}, fmt.Errorf("must be recipient ids []string or []RecipientCreateParams, got %T", v)
+}
@@ -0,0 +1,110 @@
+package onfleet
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskDestination_JSON(t *testing.T) {
+	address := DestinationAddress{Number: "1", Street: "Main St", City: "Springfield", Country: "USA"}
+	tests := []struct {
+		name        string
+		destination any
+		want        string
+	}{
+		{"by id", DestinationByID("dest_1"), `"dest_1"`},
+		{"new", NewDestination(DestinationCreateParams{Address: address}), `{"address":{"apartment":"","city":"Springfield","country":"USA","number":"1","postalCode":"","state":"","street":"Main St"}}`},
+		// the values accepted before TaskDestination encode the same way
+		{"string", "dest_1", `"dest_1"`},
+		{"params", DestinationCreateParams{Address: address}, `{"address":{"apartment":"","city":"Springfield","country":"USA","number":"1","postalCode":"","state":"","street":"Main St"}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(TaskParams{Destination: tt.destination})
+			require.NoError(t, err)
+			var fields map[string]json.RawMessage
+			require.NoError(t, json.Unmarshal(data, &fields))
+			assert.JSONEq(t, tt.want, string(fields["destination"]))
+
+			var decoded TaskDestination
+			require.NoError(t, json.Unmarshal(fields["destination"], &decoded))
+			parsed, err := ParseTaskDestination(tt.destination)
+			require.NoError(t, err)
+			assert.Equal(t, parsed, decoded)
+		})
+	}
+
+	data, err := json.Marshal(TaskDestination{})
+	require.NoError(t, err)
+	assert.Equal(t, "null", string(data))
+}
+
+func TestTaskRecipients_JSON(t *testing.T) {
+	tests := []struct {
+		name       string
+		recipients any
+		want       string
+	}{
+		{"by ids", RecipientsByIDs("rec_1", "rec_2"), `["rec_1","rec_2"]`},
+		{"new", NewRecipients(RecipientCreateParams{Name: "Jane", Phone: "+15551234567"}), `[{"name":"Jane","phone":"+15551234567"}]`},
+		{"strings", []string{"rec_1", "rec_2"}, `["rec_1","rec_2"]`},
+		{"params", []RecipientCreateParams{{Name: "Jane", Phone: "+15551234567"}}, `[{"name":"Jane","phone":"+15551234567"}]`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(TaskCloneOverridesParam{Recipients: tt.recipients})
+			require.NoError(t, err)
+			var fields map[string]json.RawMessage
+			require.NoError(t, json.Unmarshal(data, &fields))
+			assert.JSONEq(t, tt.want, string(fields["recipients"]))
+
+			var decoded TaskRecipients
+			require.NoError(t, json.Unmarshal(fields["recipients"], &decoded))
+			parsed, err := ParseTaskRecipients(tt.recipients)
+			require.NoError(t, err)
+			assert.Equal(t, parsed, decoded)
+		})
+	}
+}
+
+func TestParseTaskDestination_Decoded(t *testing.T) {
+	// TaskParams decoded from a response keep the json shapes
+	var params TaskParams
+	require.NoError(t, json.Unmarshal([]byte(`{"destination": {"address": {"unparsed": "1 Main St"}}, "recipients": ["rec_1"]}`), &params))
+
+	destination, err := ParseTaskDestination(params.Destination)
+	require.NoError(t, err)
+	destinationParams, ok := destination.Params()
+	require.True(t, ok)
+	assert.Equal(t, "1 Main St", destinationParams.Address.Unparsed)
+
+	recipients, err := ParseTaskRecipients(params.Recipients)
+	require.NoError(t, err)
+	ids, ok := recipients.IDs()
+	require.True(t, ok)
+	assert.Equal(t, []string{"rec_1"}, ids)
+
+	_, err = ParseTaskDestination(42)
+	assert.EqualError(t, err, "must be a destination id or DestinationCreateParams, got int")
+}
+
+func TestTaskParams_Validate_Unions(t *testing.T) {
+	assert.NoError(t, TaskParams{
+		Destination: DestinationByID("dest_1"),
+		Recipients:  RecipientsByIDs("rec_1"),
+	}.Validate())
+
+	err := TaskParams{
+		Destination: NewDestination(DestinationCreateParams{}),
+		Recipients:  NewRecipients(RecipientCreateParams{Phone: "+15551234567"}),
+	}.Validate()
+	fields := fieldErrors(t, err)
+	assert.Equal(t, "is required unless unparsed is set", fields["destination.address.street"])
+	assert.Equal(t, "is required", fields["recipients[0].name"])
+
+	err = TaskParams{Destination: DestinationByID("")}.Validate()
+	assert.Equal(t, map[string]string{"destination": "must not be an empty id"}, fieldErrors(t, err))
+}
@@ -20,12 +20,14 @@ type TeamCreateParams struct {
 	Workers              []string `json:"workers"`
 }
 
+// TeamUpdateParams only sends the fields set, nil fields are left unchanged.
+// An empty, non nil, Workers removes every worker.
 type TeamUpdateParams struct {
-	EnableSelfAssignment bool     `json:"enableSelfAssignment"`
-	Hub                  string   `json:"hub,omitempty"`
-	Managers             []string `json:"managers"`
-	Name                 string   `json:"name"`
-	Workers              []string `json:"workers"`
+	EnableSelfAssignment *bool     `json:"enableSelfAssignment,omitempty"`
+	Hub                  string    `json:"hub,omitempty"`
+	Managers             *[]string `json:"managers,omitempty"`
+	Name                 string    `json:"name,omitempty"`
+	Workers              *[]string `json:"workers,omitempty"`
 }
 
 type TeamAutoDispatch struct {
@@ -363,9 +363,9 @@ func GetSampleTaskParams() onfleet.TaskParams {
 				Notes: "Call upon arrival",
 			},
 		},
-		PickupTask: false,
-		Quantity:   2.0,
-		ServiceTime: 10.0,
+		PickupTask: GetBoolPtr(false),
+		Quantity:   GetFloat64Ptr(2.0),
+		ServiceTime: GetFloat64Ptr(10.0),
 		Notes: "Handle with care",
 		CompleteAfter: 1641002400,  // 2022-01-01 02:00:00 UTC (future)
 		CompleteBefore: 1641006000, // 2022-01-01 03:00:00 UTC (future)
@@ -1,13 +1,17 @@
 package testingutil
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"strings"
 	"testing"
 
+	onfleet "github.com/onfleet/gonfleet"
 	"github.com/onfleet/gonfleet/netwrk"
 )
 
@@ -44,6 +48,7 @@ func (m *MockHTTPClient) AddResponse(urlPattern string, response MockResponse) {
 
 // MockCaller is a test implementation of netwrk.Caller that uses the mock HTTP client
 func (m *MockHTTPClient) MockCaller(
+	ctx context.Context,
 	apiKey string,
 	rlHttpClient *netwrk.RlHttpClient,
 	method string,
@@ -73,6 +78,9 @@ func (m *MockHTTPClient) MockCaller(
 		URL:    parsedURL,
 		Header: make(http.Header),
 	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
 	req.SetBasicAuth(apiKey, "")
 	
 	// Add additional headers
@@ -85,6 +93,11 @@ func (m *MockHTTPClient) MockCaller(
 	// Store request in history
 	m.RequestHistory = append(m.RequestHistory, req)
 
+	// Mirror netwrk.Call which never reaches the network with a done context
+	if ctx != nil && ctx.Err() != nil {
+		return ctx.Err()
+	}
+
 	// Find matching response
 	var response MockResponse
 	var found bool
@@ -108,9 +121,21 @@ func (m *MockHTTPClient) MockCaller(
 		return fmt.Errorf("no mock response found for URL: %s", fullURL)
 	}
 
-	// Simulate HTTP status code errors
+	// Simulate HTTP status code errors the way netwrk.Call parses them
 	if response.StatusCode >= 400 {
-		return fmt.Errorf("HTTP %d error", response.StatusCode)
+		bodyBytes, err := json.Marshal(response.Body)
+		if err != nil {
+			return err
+		}
+		header := make(http.Header)
+		for k, v := range response.Headers {
+			header.Set(k, v)
+		}
+		return onfleet.ParseResponseError(&http.Response{
+			StatusCode: response.StatusCode,
+			Header:     header,
+			Body:       io.NopCloser(bytes.NewReader(bodyBytes)),
+		})
 	}
 
 	// Marshal response body and unmarshal into target
@@ -178,4 +203,51 @@ func (m *MockHTTPClient) AssertBasicAuth(expectedAPIKey string) {
 func (m *MockHTTPClient) Reset() {
 	m.RequestHistory = make([]*http.Request, 0)
 	m.Responses = make(map[string]MockResponse)
-}
\ No newline at end of file
+}
+
+// PaginatedCaller returns a netwrk.Caller serving pages keyed by the lastId
+// query parameter, "" being the first page. LastIds records the lastId of
+// every call made.
+type PaginatedCaller struct {
+	Pages   map[string]interface{}
+	LastIds []string
+}
+
+// Call implements netwrk.Caller
+func (p *PaginatedCaller) Call(
+	ctx context.Context,
+	apiKey string,
+	rlHttpClient *netwrk.RlHttpClient,
+	method string,
+	baseUrl string,
+	pathSegments []string,
+	queryParams any,
+	body any,
+	v any,
+	additionalHeaders ...[2]string,
+) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	query := struct {
+		LastId string `json:"lastId"`
+	}{}
+	queryBytes, err := json.Marshal(queryParams)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(queryBytes, &query); err != nil {
+		return err
+	}
+	p.LastIds = append(p.LastIds, query.LastId)
+
+	page, ok := p.Pages[query.LastId]
+	if !ok {
+		return fmt.Errorf("no mock page found for lastId: %q", query.LastId)
+	}
+	bodyBytes, err := json.Marshal(page)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(bodyBytes, v)
+}
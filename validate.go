@@ -0,0 +1,280 @@
+package onfleet
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FieldError is a problem with one field of a params struct.
+type FieldError struct {
+	// Field is the json path of the field e.g. "destination.address.city".
+	Field   string
+	Message string
+}
+
+func (err FieldError) Error() string {
+	return err.Field + ": " + err.Message
+}
+
+// ValidationError lists every problem found by a Validate method.
+// errors.As(err, &onfleet.FieldError{}) finds the first one.
+type ValidationError struct {
+	// Params is the type validated e.g. "TaskParams".
+	Params string
+	Errors []FieldError
+}
+
+func (err ValidationError) Error() string {
+	problems := make([]string, len(err.Errors))
+	for i, fieldError := range err.Errors {
+		problems[i] = fieldError.Error()
+	}
+	return fmt.Sprintf("invalid %s: %s", err.Params, strings.Join(problems, "; "))
+}
+
+func (err ValidationError) Unwrap() []error {
+	errs := make([]error, len(err.Errors))
+	for i, fieldError := range err.Errors {
+		errs[i] = fieldError
+	}
+	return errs
+}
+
+// validator collects FieldErrors under a field path prefix.
+type validator struct {
+	prefix string
+	errs   *[]FieldError
+}
+
+func newValidator() validator {
+	return validator{errs: &[]FieldError{}}
+}
+
+// at returns a validator for the nested field.
+func (v validator) at(field string) validator {
+	return validator{prefix: v.field(field), errs: v.errs}
+}
+
+func (v validator) field(field string) string {
+	if v.prefix == "" {
+		return field
+	}
+	if strings.HasPrefix(field, "[") {
+		return v.prefix + field
+	}
+	return v.prefix + "." + field
+}
+
+// check records a problem with field unless ok.
+func (v validator) check(ok bool, field string, format string, args ...any) {
+	if !ok {
+		*v.errs = append(*v.errs, FieldError{Field: v.field(field), Message: fmt.Sprintf(format, args...)})
+	}
+}
+
+func (v validator) result(params string) error {
+	if len(*v.errs) == 0 {
+		return nil
+	}
+	return ValidationError{Params: params, Errors: *v.errs}
+}
+
+const maxTriangleColor = 5
+
+// Validate checks TaskParams for problems the API would reject with
+// InvalidArgument. Fields required on creation only, e.g. Destination,
+// are not required so that the same params can be used for updates.
+func (p TaskParams) Validate() error {
+	v := newValidator()
+	p.validate(v)
+	return v.result("TaskParams")
+}
+
+func (p TaskParams) validate(v validator) {
+	v.check(p.CompleteAfter >= 0, "completeAfter", "must not be negative")
+	v.check(p.CompleteBefore >= 0, "completeBefore", "must not be negative")
+	v.check(p.CompleteAfter == 0 || p.CompleteBefore == 0 || p.CompleteAfter <= p.CompleteBefore,
+		"completeAfter", "must not be after completeBefore")
+	v.check(p.Quantity == nil || *p.Quantity >= 0, "quantity", "must not be negative")
+	v.check(p.ServiceTime == nil || *p.ServiceTime >= 0, "serviceTime", "must not be negative")
+	if p.Appearance != nil {
+		v.check(p.Appearance.TriangleColor >= 0 && p.Appearance.TriangleColor <= maxTriangleColor,
+			"appearance.triangleColor", "must be between 0 and %d", maxTriangleColor)
+	}
+	if p.AutoAssign != nil {
+		mode := p.AutoAssign.Mode
+		v.check(mode == TaskAutoAssignModeDistance || mode == TaskAutoAssignModeLoad,
+			"autoAssign.mode", "must be %q or %q", TaskAutoAssignModeDistance, TaskAutoAssignModeLoad)
+	}
+	for i, dependency := range p.Dependencies {
+		v.check(dependency != "", fmt.Sprintf("dependencies[%d]", i), "must not be empty")
+	}
+	validateDestination(v, p.Destination)
+	validateRecipients(v, p.Recipients)
+	validateMetadata(v, p.Metadata)
+}
+
+// Validate checks the overrides of a task clone, including that
+// Destination and Recipients hold one of their supported types.
+func (p TaskCloneOverridesParam) Validate() error {
+	v := newValidator()
+	v.check(p.CompleteAfter == 0 || p.CompleteBefore == 0 || p.CompleteAfter <= p.CompleteBefore,
+		"completeAfter", "must not be after completeBefore")
+	v.check(p.ServiceTime == nil || *p.ServiceTime >= 0, "serviceTime", "must not be negative")
+	validateDestination(v, p.Destination)
+	validateRecipients(v, p.Recipients)
+	validateMetadata(v, p.Metadata)
+	return v.result("TaskCloneOverridesParam")
+}
+
+// validateMetadata checks every entry with Metadata.Validate.
+func validateMetadata(v validator, metadata []Metadata) {
+	for i, m := range metadata {
+		err := m.Validate()
+		v.check(err == nil, fmt.Sprintf("metadata[%d]", i), "%v", err)
+	}
+}
+
+// validateDestination checks a value accepted by TaskParams.Destination.
+func validateDestination(v validator, destination any) {
+	if destination == nil {
+		return
+	}
+	d, err := ParseTaskDestination(destination)
+	if err != nil {
+		v.check(false, "destination", "%s", err)
+		return
+	}
+	if params, ok := d.Params(); ok {
+		params.validate(v.at("destination"))
+		return
+	}
+	_, ok := d.ID()
+	v.check(ok, "destination", "must not be an empty id")
+}
+
+// validateRecipients checks a value accepted by TaskParams.Recipients.
+func validateRecipients(v validator, recipients any) {
+	if recipients == nil {
+		return
+	}
+	r, err := ParseTaskRecipients(recipients)
+	if err != nil {
+		v.check(false, "recipients", "%s", err)
+		return
+	}
+	ids, _ := r.IDs()
+	for i, id := range ids {
+		v.check(id != "", fmt.Sprintf("recipients[%d]", i), "must not be an empty id")
+	}
+	params, _ := r.Params()
+	for i, recipient := range params {
+		recipient.validate(v.at(fmt.Sprintf("recipients[%d]", i)))
+	}
+}
+
+// Validate checks DestinationCreateParams for problems the API would reject.
+func (p DestinationCreateParams) Validate() error {
+	v := newValidator()
+	p.validate(v)
+	return v.result("DestinationCreateParams")
+}
+
+func (p DestinationCreateParams) validate(v validator) {
+	a := v.at("address")
+	if p.Address.Unparsed == "" {
+		a.check(p.Address.Number != "", "number", "is required unless unparsed is set")
+		a.check(p.Address.Street != "", "street", "is required unless unparsed is set")
+		a.check(p.Address.City != "", "city", "is required unless unparsed is set")
+		a.check(p.Address.Country != "", "country", "is required unless unparsed is set")
+	}
+	if p.Location != nil {
+		err := p.Location.Validate()
+		v.check(err == nil, "location", "%v", err)
+	}
+	validateMetadata(v, p.Metadata)
+}
+
+// Validate checks RecipientCreateParams for problems the API would reject.
+func (p RecipientCreateParams) Validate() error {
+	v := newValidator()
+	p.validate(v)
+	return v.result("RecipientCreateParams")
+}
+
+func (p RecipientCreateParams) validate(v validator) {
+	v.check(p.Name != "", "name", "is required")
+	v.check(p.Phone != "", "phone", "is required")
+	validateMetadata(v, p.Metadata)
+}
+
+// Validate checks WorkerCreateParams for problems the API would reject.
+func (p WorkerCreateParams) Validate() error {
+	v := newValidator()
+	v.check(p.Name != "", "name", "is required")
+	v.check(p.Phone != "", "phone", "is required")
+	v.check(len(p.Teams) > 0, "teams", "must contain at least one team")
+	for i, team := range p.Teams {
+		v.check(team != "", fmt.Sprintf("teams[%d]", i), "must not be empty")
+	}
+	v.check(p.Capacity >= 0, "capacity", "must not be negative")
+	validateMetadata(v, p.Metadata)
+	if p.Vehicle != nil {
+		switch p.Vehicle.Type {
+		case "", WorkerVehicleTypeCar, WorkerVehicleTypeBicycle, WorkerVehicleTypeMotorcycle, WorkerVehicleTypeTruck:
+		default:
+			v.check(false, "vehicle.type", "unknown vehicle type %q", p.Vehicle.Type)
+		}
+	}
+	return v.result("WorkerCreateParams")
+}
+
+// Validate checks RoutePlanParams for problems the API would reject.
+func (p RoutePlanParams) Validate() error {
+	v := newValidator()
+	v.check(p.Name != "", "name", "is required")
+	v.check(p.StartTime > 0, "startTime", "is required")
+	v.check(p.EndTime == 0 || p.EndTime > p.StartTime, "endTime", "must be after startTime")
+	validatePosition := func(field string, position PositionEnum, hubField string, hubId string) {
+		switch position {
+		case "", PositionEnumWorkerLocation, PositionEnumWorkerAddress:
+		case PositionEnumHub:
+			v.check(hubId != "", hubField, "is required when %s is %q", field, PositionEnumHub)
+		default:
+			v.check(false, field, "unknown position %q", position)
+		}
+	}
+	validatePosition("start", p.StartAt, "startingHubId", p.StartingHubId)
+	validatePosition("end", p.EndAt, "endingHubId", p.EndingHubId)
+	return v.result("RoutePlanParams")
+}
+
+// Validate checks TeamAutoDispatchParams for problems the API would reject.
+func (p TeamAutoDispatchParams) Validate() error {
+	v := newValidator()
+	v.check(p.MaxAllowedDelay >= 0, "maxAllowedDelay", "must not be negative")
+	v.check(p.MaxTasksPerRoute >= 0, "maxTasksPerRoute", "must not be negative")
+	v.check(p.ServiceTime >= 0, "serviceTime", "must not be negative")
+	validateWindow := func(field string, window []int64) {
+		if len(window) == 0 {
+			return
+		}
+		v.check(len(window) == 2, field, "must be a [start, end] pair")
+		if len(window) == 2 {
+			v.check(window[0] >= 0 && window[0] <= window[1], field, "start must not be after end")
+		}
+	}
+	validateWindow("scheduleTimeWindow", p.ScheduleTimeWindow)
+	validateWindow("taskTimeWindow", p.TaskTimeWindow)
+	return v.result("TeamAutoDispatchParams")
+}
+
+// Validate checks every task of the batch, fields are reported as
+// "tasks[i].field".
+func (p TaskBatchCreateParams) Validate() error {
+	v := newValidator()
+	for i, task := range p.Tasks {
+		task.validate(v.at(fmt.Sprintf("tasks[%d]", i)))
+	}
+	return v.result("TaskBatchCreateParams")
+}
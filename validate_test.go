@@ -0,0 +1,133 @@
+package onfleet
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fieldErrors(t *testing.T, err error) map[string]string {
+	t.Helper()
+	var validationError ValidationError
+	require.True(t, errors.As(err, &validationError), "got %v", err)
+	fields := map[string]string{}
+	for _, fieldError := range validationError.Errors {
+		fields[fieldError.Field] = fieldError.Message
+	}
+	return fields
+}
+
+func TestTaskParams_Validate(t *testing.T) {
+	valid := TaskParams{
+		CompleteAfter:  1000,
+		CompleteBefore: 2000,
+		Destination: DestinationCreateParams{
+			Address: DestinationAddress{Number: "1", Street: "Main St", City: "Springfield", Country: "USA"},
+		},
+		Recipients: []RecipientCreateParams{{Name: "Jane", Phone: "+15551234567"}},
+		Quantity:   Ptr(2.0),
+		Appearance: &TaskAppearanceParam{TriangleColor: 5},
+	}
+	assert.NoError(t, valid.Validate())
+	assert.NoError(t, TaskParams{}.Validate())
+	assert.NoError(t, TaskParams{Destination: "dest_1", Recipients: []string{"rec_1"}}.Validate())
+	assert.NoError(t, TaskParams{Destination: &DestinationCreateParams{Address: DestinationAddress{Unparsed: "1 Main St"}}}.Validate())
+
+	err := TaskParams{
+		CompleteAfter:  3000,
+		CompleteBefore: 2000,
+		Destination:    DestinationCreateParams{Address: DestinationAddress{Street: "Main St"}},
+		Recipients:     []RecipientCreateParams{{Name: "Jane"}},
+		Quantity:       Ptr(-1.0),
+		Appearance:     &TaskAppearanceParam{TriangleColor: 6},
+		AutoAssign:     &TaskAutoAssignParam{Mode: "nearest"},
+	}.Validate()
+
+	assert.Equal(t, map[string]string{
+		"completeAfter":               "must not be after completeBefore",
+		"destination.address.number":  "is required unless unparsed is set",
+		"destination.address.city":    "is required unless unparsed is set",
+		"destination.address.country": "is required unless unparsed is set",
+		"recipients[0].phone":         "is required",
+		"quantity":                    "must not be negative",
+		"appearance.triangleColor":    "must be between 0 and 5",
+		"autoAssign.mode":             `must be "distance" or "load"`,
+	}, fieldErrors(t, err))
+	assert.Contains(t, err.Error(), "invalid TaskParams: completeAfter: must not be after completeBefore; ")
+
+	var fieldError FieldError
+	require.True(t, errors.As(err, &fieldError))
+	assert.Equal(t, "completeAfter", fieldError.Field)
+}
+
+func TestTaskParams_Validate_Shapes(t *testing.T) {
+	err := TaskParams{
+		Destination: map[string]string{"address": "1 Main St"},
+		Recipients:  []int{1},
+	}.Validate()
+	fields := fieldErrors(t, err)
+	assert.Equal(t, "must be a destination id or DestinationCreateParams, got map[string]string", fields["destination"])
+	assert.Equal(t, "must be recipient ids []string or []RecipientCreateParams, got []int", fields["recipients"])
+
+	err = TaskParams{Destination: "", Recipients: []string{"rec_1", ""}}.Validate()
+	assert.Equal(t, map[string]string{
+		"destination":   "must not be an empty id",
+		"recipients[1]": "must not be an empty id",
+	}, fieldErrors(t, err))
+}
+
+func TestTaskCloneOverridesParam_Validate(t *testing.T) {
+	assert.NoError(t, TaskCloneOverridesParam{Destination: "dest_1", Recipients: RecipientsByIDs("rec_1")}.Validate())
+
+	err := TaskCloneOverridesParam{
+		Destination: 42,
+		Recipients:  "rec_1",
+	}.Validate()
+	assert.Equal(t, map[string]string{
+		"destination": "must be a destination id or DestinationCreateParams, got int",
+		"recipients":  "must be recipient ids []string or []RecipientCreateParams, got string",
+	}, fieldErrors(t, err))
+}
+
+func TestTaskBatchCreateParams_Validate(t *testing.T) {
+	err := TaskBatchCreateParams{Tasks: []TaskParams{{}, {Quantity: Ptr(-1.0)}}}.Validate()
+	assert.Equal(t, map[string]string{"tasks[1].quantity": "must not be negative"}, fieldErrors(t, err))
+}
+
+func TestWorkerCreateParams_Validate(t *testing.T) {
+	assert.NoError(t, WorkerCreateParams{Name: "Jane", Phone: "+15551234567", Teams: []string{"team_1"}}.Validate())
+
+	err := WorkerCreateParams{Capacity: -1, Vehicle: &WorkerVehicleParam{Type: "BOAT"}}.Validate()
+	assert.Equal(t, map[string]string{
+		"name":         "is required",
+		"phone":        "is required",
+		"teams":        "must contain at least one team",
+		"capacity":     "must not be negative",
+		"vehicle.type": `unknown vehicle type "BOAT"`,
+	}, fieldErrors(t, err))
+}
+
+func TestRoutePlanParams_Validate(t *testing.T) {
+	assert.NoError(t, RoutePlanParams{Name: "Morning", StartTime: 1000, StartAt: PositionEnumHub, StartingHubId: "hub_1"}.Validate())
+
+	err := RoutePlanParams{StartTime: 2000, EndTime: 1000, StartAt: PositionEnumHub, EndAt: "MOON"}.Validate()
+	assert.Equal(t, map[string]string{
+		"name":          "is required",
+		"endTime":       "must be after startTime",
+		"startingHubId": `is required when start is "HUB"`,
+		"end":           `unknown position "MOON"`,
+	}, fieldErrors(t, err))
+}
+
+func TestTeamAutoDispatchParams_Validate(t *testing.T) {
+	assert.NoError(t, TeamAutoDispatchParams{MaxTasksPerRoute: 10, TaskTimeWindow: []int64{8, 17}}.Validate())
+
+	err := TeamAutoDispatchParams{ServiceTime: -5, ScheduleTimeWindow: []int64{1}, TaskTimeWindow: []int64{17, 8}}.Validate()
+	assert.Equal(t, map[string]string{
+		"serviceTime":        "must not be negative",
+		"scheduleTimeWindow": "must be a [start, end] pair",
+		"taskTimeWindow":     "start must not be after end",
+	}, fieldErrors(t, err))
+}
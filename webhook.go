@@ -1,18 +1,54 @@
 package onfleet
 
+import "encoding/json"
+
 type Webhook struct {
-	Count     int64   `json:"count"`
-	ID        string  `json:"id"`
-	IsEnabled bool    `json:"isEnabled"`
-	Name      string  `json:"name"`
-	Threshold float64 `json:"threshold,omitempty"`
-	Trigger   int     `json:"trigger"`
-	Url       string  `json:"url"`
+	Count     int64          `json:"count"`
+	ID        string         `json:"id"`
+	IsEnabled bool           `json:"isEnabled"`
+	Name      string         `json:"name"`
+	Threshold float64        `json:"threshold,omitempty"`
+	Trigger   WebhookTrigger `json:"trigger"`
+	Url       string         `json:"url"`
 }
 
 type WebhookCreateParams struct {
-	Name      string  `json:"name"`
-	Threshold float64 `json:"threshold,omitempty"`
-	Trigger   int     `json:"trigger"`
-	Url       string  `json:"url"`
+	Name      string         `json:"name"`
+	Threshold float64        `json:"threshold,omitempty"`
+	Trigger   WebhookTrigger `json:"trigger"`
+	Url       string         `json:"url"`
+}
+
+type WebhookUpdateParams struct {
+	// IsEnabled re-enables a webhook disabled after failed deliveries.
+	IsEnabled *bool    `json:"isEnabled,omitempty"`
+	Name      string   `json:"name,omitempty"`
+	Threshold *float64 `json:"threshold,omitempty"`
+	Url       string   `json:"url,omitempty"`
+}
+
+// WebhookPayload is the body Onfleet posts to a webhook url.
+// Event decodes it into the typed event of its trigger.
+// Reference https://docs.onfleet.com/reference/webhook-payload-examples
+type WebhookPayload struct {
+	WebhookEventMeta
+	// Data holds the trigger specific payload e.g. the task and worker.
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+// WebhookEventMeta holds the fields shared by every webhook payload.
+type WebhookEventMeta struct {
+	ActionContext *WebhookActionContext `json:"actionContext,omitempty"`
+	AdminId       *string               `json:"adminId"`
+	TaskId        string                `json:"taskId,omitempty"`
+	Time          int64                 `json:"time"`
+	TriggerId     WebhookTrigger        `json:"triggerId"`
+	TriggerName   string                `json:"triggerName"`
+	WorkerId      *string               `json:"workerId"`
+}
+
+// WebhookActionContext identifies who caused the event.
+type WebhookActionContext struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
 }
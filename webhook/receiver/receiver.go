@@ -0,0 +1,223 @@
+// Package receiver serves Onfleet webhook deliveries.
+//
+// Reference https://docs.onfleet.com/reference/webhooks
+package receiver
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/onfleet/gonfleet"
+)
+
+// SignatureHeader carries the hex encoded HMAC-SHA512 of the request body.
+const SignatureHeader = "X-Onfleet-Signature"
+
+const (
+	defaultTolerance   = 5 * time.Minute
+	defaultMaxBodySize = 1 << 20
+)
+
+// HandlerFunc handles a verified and decoded webhook delivery.
+// Returning an error responds with a 500 so Onfleet treats the delivery as failed.
+type HandlerFunc func(ctx context.Context, payload onfleet.WebhookPayload) error
+
+// Params overrides Receiver defaults.
+type Params struct {
+	// Tolerance is the maximum age of a payload, judged by its time field.
+	// Deliveries already handled, or being handled, within this window are
+	// acknowledged without calling the handler again. Defaults to 5 minutes, negative disables
+	// both checks.
+	Tolerance time.Duration
+	// MaxBodySize limits the request body in bytes. Defaults to 1MB.
+	MaxBodySize int64
+	// OnError, if set, is called with every rejected delivery or handler error.
+	OnError func(r *http.Request, err error)
+}
+
+// Receiver is an http.Handler for Onfleet webhooks. It answers the
+// ?check= validation request, verifies the X-Onfleet-Signature of deliveries,
+// rejects stale or replayed deliveries and decodes the payload for the handler.
+type Receiver struct {
+	secret      []byte
+	handler     HandlerFunc
+	tolerance   time.Duration
+	maxBodySize int64
+	onError     func(r *http.Request, err error)
+	now         func() time.Time
+
+	mu   sync.Mutex
+	seen map[string]time.Time
+}
+
+var (
+	ErrMissingSignature = errors.New("missing webhook signature")
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	ErrStalePayload     = errors.New("webhook payload outside tolerance")
+)
+
+// New creates a Receiver. secret is the hex encoded webhook secret found in
+// the Onfleet dashboard.
+func New(secret string, handler HandlerFunc, params *Params) (*Receiver, error) {
+	key, err := hex.DecodeString(secret)
+	if err != nil || len(key) == 0 {
+		return nil, fmt.Errorf("webhook secret must be a non empty hex string")
+	}
+	if handler == nil {
+		return nil, fmt.Errorf("webhook handler not found")
+	}
+	r := &Receiver{
+		secret:      key,
+		handler:     handler,
+		tolerance:   defaultTolerance,
+		maxBodySize: defaultMaxBodySize,
+		now:         time.Now,
+		seen:        map[string]time.Time{},
+	}
+	if params != nil {
+		if params.Tolerance != 0 {
+			r.tolerance = params.Tolerance
+		}
+		if params.MaxBodySize > 0 {
+			r.maxBodySize = params.MaxBodySize
+		}
+		r.onError = params.OnError
+	}
+	return r, nil
+}
+
+// Sign returns the X-Onfleet-Signature value of body for the hex encoded secret.
+func Sign(secret string, body []byte) (string, error) {
+	key, err := hex.DecodeString(secret)
+	if err != nil {
+		return "", err
+	}
+	return sign(key, body), nil
+}
+
+func sign(key []byte, body []byte) string {
+	mac := hmac.New(sha512.New, key)
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks signature against body.
+func (rc *Receiver) Verify(body []byte, signature string) error {
+	if signature == "" {
+		return ErrMissingSignature
+	}
+	given, err := hex.DecodeString(signature)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	mac := hmac.New(sha512.New, rc.secret)
+	mac.Write(body)
+	if !hmac.Equal(given, mac.Sum(nil)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+func (rc *Receiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		// Onfleet validates a webhook url by expecting the check value echoed back
+		check := r.URL.Query().Get("check")
+		if check == "" {
+			rc.reject(w, r, http.StatusBadRequest, errors.New("missing check parameter"))
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain")
+		io.WriteString(w, check)
+	case http.MethodPost:
+		rc.serveDelivery(w, r)
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		rc.reject(w, r, http.StatusMethodNotAllowed, fmt.Errorf("unsupported method: %s", r.Method))
+	}
+}
+
+func (rc *Receiver) serveDelivery(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, rc.maxBodySize))
+	if err != nil {
+		rc.reject(w, r, http.StatusRequestEntityTooLarge, err)
+		return
+	}
+	signature := r.Header.Get(SignatureHeader)
+	if err := rc.Verify(body, signature); err != nil {
+		rc.reject(w, r, http.StatusUnauthorized, err)
+		return
+	}
+
+	var payload onfleet.WebhookPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		rc.reject(w, r, http.StatusBadRequest, fmt.Errorf("decoding webhook payload: %w", err))
+		return
+	}
+
+	now := rc.now()
+	if rc.tolerance > 0 {
+		sent := time.UnixMilli(payload.Time)
+		if payload.Time == 0 || now.Sub(sent) > rc.tolerance || sent.Sub(now) > rc.tolerance {
+			rc.reject(w, r, http.StatusBadRequest, ErrStalePayload)
+			return
+		}
+		if !rc.reserve(signature, now) {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+	}
+
+	if err := rc.handler(r.Context(), payload); err != nil {
+		if rc.tolerance > 0 {
+			rc.release(signature)
+		}
+		rc.reject(w, r, http.StatusInternalServerError, err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// reserve remembers signature for the tolerance window, dropping expired
+// entries. It reports false if a delivery with signature was already handled
+// or is being handled, so concurrent duplicates call the handler once.
+func (rc *Receiver) reserve(signature string, now time.Time) bool {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	if expires, ok := rc.seen[signature]; ok && now.Before(expires) {
+		return false
+	}
+	for s, expires := range rc.seen {
+		if !now.Before(expires) {
+			delete(rc.seen, s)
+		}
+	}
+	// a delivery may be up to tolerance in the future, keep it until it could
+	// no longer pass the age check
+	rc.seen[signature] = now.Add(2 * rc.tolerance)
+	return true
+}
+
+// release forgets signature after its handler failed, so that Onfleet's
+// retry of the delivery is handled.
+func (rc *Receiver) release(signature string) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	delete(rc.seen, signature)
+}
+
+func (rc *Receiver) reject(w http.ResponseWriter, r *http.Request, status int, err error) {
+	if rc.onError != nil {
+		rc.onError(r, err)
+	}
+	http.Error(w, http.StatusText(status), status)
+}
@@ -0,0 +1,276 @@
+package receiver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/onfleet/gonfleet"
+)
+
+const testSecret = "8a3f0e1b2c4d"
+
+var testNow = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func newTestReceiver(t *testing.T, handler HandlerFunc, params *Params) *Receiver {
+	t.Helper()
+	rc, err := New(testSecret, handler, params)
+	assert.NoError(t, err)
+	rc.now = func() time.Time { return testNow }
+	return rc
+}
+
+func delivery(t *testing.T, body string, signature string) *http.Request {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/onfleet", strings.NewReader(body))
+	if signature != "" {
+		req.Header.Set(SignatureHeader, signature)
+	}
+	return req
+}
+
+func signedDelivery(t *testing.T, body string) *http.Request {
+	t.Helper()
+	signature, err := Sign(testSecret, []byte(body))
+	assert.NoError(t, err)
+	return delivery(t, body, signature)
+}
+
+func samplePayload(at time.Time) string {
+	return fmt.Sprintf(`{"taskId":"task_123","time":%d,"triggerId":3,"triggerName":"taskCompleted","workerId":"worker_123","adminId":null,"data":{"task":{"id":"task_123"}},"actionContext":{"id":"worker_123","type":"WORKER"}}`, at.UnixMilli())
+}
+
+func TestNew_InvalidSecret(t *testing.T) {
+	handler := func(ctx context.Context, payload onfleet.WebhookPayload) error { return nil }
+
+	_, err := New("not-hex", handler, nil)
+	assert.Error(t, err)
+
+	_, err = New("", handler, nil)
+	assert.Error(t, err)
+
+	_, err = New(testSecret, nil, nil)
+	assert.Error(t, err)
+}
+
+func TestReceiver_ValidationCheck(t *testing.T) {
+	rc := newTestReceiver(t, func(ctx context.Context, payload onfleet.WebhookPayload) error {
+		t.Error("handler should not be called for validation")
+		return nil
+	}, nil)
+
+	w := httptest.NewRecorder()
+	rc.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/onfleet?check=abc123", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "abc123", w.Body.String())
+
+	w = httptest.NewRecorder()
+	rc.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/onfleet", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestReceiver_Delivery(t *testing.T) {
+	var received onfleet.WebhookPayload
+	rc := newTestReceiver(t, func(ctx context.Context, payload onfleet.WebhookPayload) error {
+		received = payload
+		return nil
+	}, nil)
+
+	w := httptest.NewRecorder()
+	rc.ServeHTTP(w, signedDelivery(t, samplePayload(testNow)))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "task_123", received.TaskId)
+	assert.Equal(t, onfleet.WebhookTriggerTaskCompleted, received.TriggerId)
+	assert.Equal(t, "taskCompleted", received.TriggerName)
+	assert.Equal(t, "worker_123", *received.WorkerId)
+	assert.Nil(t, received.AdminId)
+	assert.Equal(t, "WORKER", received.ActionContext.Type)
+	assert.JSONEq(t, `{"task":{"id":"task_123"}}`, string(received.Data))
+}
+
+func TestReceiver_Signature(t *testing.T) {
+	tests := []struct {
+		name      string
+		signature string
+		expected  error
+	}{
+		{name: "missing", signature: "", expected: ErrMissingSignature},
+		{name: "not hex", signature: "zzzz", expected: ErrInvalidSignature},
+		{name: "wrong", signature: strings.Repeat("ab", 64), expected: ErrInvalidSignature},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var rejected error
+			rc := newTestReceiver(t, func(ctx context.Context, payload onfleet.WebhookPayload) error {
+				t.Error("handler should not be called")
+				return nil
+			}, &Params{OnError: func(r *http.Request, err error) { rejected = err }})
+
+			w := httptest.NewRecorder()
+			rc.ServeHTTP(w, delivery(t, samplePayload(testNow), tt.signature))
+
+			assert.Equal(t, http.StatusUnauthorized, w.Code)
+			assert.ErrorIs(t, rejected, tt.expected)
+		})
+	}
+}
+
+func TestReceiver_TamperedBody(t *testing.T) {
+	rc := newTestReceiver(t, func(ctx context.Context, payload onfleet.WebhookPayload) error {
+		t.Error("handler should not be called")
+		return nil
+	}, nil)
+
+	signature, _ := Sign(testSecret, []byte(samplePayload(testNow)))
+	tampered := strings.Replace(samplePayload(testNow), "task_123", "task_999", 1)
+
+	w := httptest.NewRecorder()
+	rc.ServeHTTP(w, delivery(t, tampered, signature))
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestReceiver_Replay(t *testing.T) {
+	calls := 0
+	rc := newTestReceiver(t, func(ctx context.Context, payload onfleet.WebhookPayload) error {
+		calls++
+		return nil
+	}, nil)
+	body := samplePayload(testNow)
+
+	for i := 0; i < 2; i++ {
+		w := httptest.NewRecorder()
+		rc.ServeHTTP(w, signedDelivery(t, body))
+		assert.Equal(t, http.StatusOK, w.Code)
+	}
+	assert.Equal(t, 1, calls)
+}
+
+func TestReceiver_ConcurrentReplay(t *testing.T) {
+	var calls int32
+	release := make(chan struct{})
+	rc := newTestReceiver(t, func(ctx context.Context, payload onfleet.WebhookPayload) error {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return nil
+	}, nil)
+	body := samplePayload(testNow)
+
+	var wg sync.WaitGroup
+	codes := make([]int, 8)
+	for i := range codes {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			w := httptest.NewRecorder()
+			rc.ServeHTTP(w, signedDelivery(t, body))
+			codes[i] = w.Code
+		}(i)
+	}
+	// the duplicates are answered while the first delivery is still handled
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&calls) == 1 }, time.Second, time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	for _, code := range codes {
+		assert.Equal(t, http.StatusOK, code)
+	}
+}
+
+func TestReceiver_RetryAfterHandlerError(t *testing.T) {
+	calls := 0
+	rc := newTestReceiver(t, func(ctx context.Context, payload onfleet.WebhookPayload) error {
+		calls++
+		if calls == 1 {
+			return errors.New("database down")
+		}
+		return nil
+	}, nil)
+	body := samplePayload(testNow)
+
+	w := httptest.NewRecorder()
+	rc.ServeHTTP(w, signedDelivery(t, body))
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+
+	w = httptest.NewRecorder()
+	rc.ServeHTTP(w, signedDelivery(t, body))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, 2, calls)
+}
+
+func TestReceiver_StalePayload(t *testing.T) {
+	rc := newTestReceiver(t, func(ctx context.Context, payload onfleet.WebhookPayload) error {
+		t.Error("handler should not be called")
+		return nil
+	}, nil)
+
+	for _, at := range []time.Time{testNow.Add(-10 * time.Minute), testNow.Add(10 * time.Minute), time.UnixMilli(0)} {
+		w := httptest.NewRecorder()
+		rc.ServeHTTP(w, signedDelivery(t, samplePayload(at)))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestReceiver_ToleranceDisabled(t *testing.T) {
+	calls := 0
+	rc := newTestReceiver(t, func(ctx context.Context, payload onfleet.WebhookPayload) error {
+		calls++
+		return nil
+	}, &Params{Tolerance: -1})
+	body := samplePayload(testNow.Add(-time.Hour))
+
+	for i := 0; i < 2; i++ {
+		w := httptest.NewRecorder()
+		rc.ServeHTTP(w, signedDelivery(t, body))
+		assert.Equal(t, http.StatusOK, w.Code)
+	}
+	assert.Equal(t, 2, calls)
+}
+
+func TestReceiver_InvalidPayload(t *testing.T) {
+	rc := newTestReceiver(t, func(ctx context.Context, payload onfleet.WebhookPayload) error {
+		t.Error("handler should not be called")
+		return nil
+	}, nil)
+
+	w := httptest.NewRecorder()
+	rc.ServeHTTP(w, signedDelivery(t, "not json"))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestReceiver_BodyTooLarge(t *testing.T) {
+	rc := newTestReceiver(t, func(ctx context.Context, payload onfleet.WebhookPayload) error {
+		return nil
+	}, &Params{MaxBodySize: 16})
+
+	w := httptest.NewRecorder()
+	rc.ServeHTTP(w, signedDelivery(t, samplePayload(testNow)))
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+}
+
+func TestReceiver_UnsupportedMethod(t *testing.T) {
+	rc := newTestReceiver(t, func(ctx context.Context, payload onfleet.WebhookPayload) error {
+		return nil
+	}, nil)
+
+	w := httptest.NewRecorder()
+	rc.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/onfleet", nil))
+
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+}
@@ -0,0 +1,292 @@
+// Package router dispatches decoded webhook events to per trigger handlers.
+//
+//	r := router.New(nil)
+//	r.Use(router.Recover(), router.Logging(log.Default()))
+//	r.OnTaskCompleted(func(ctx context.Context, event onfleet.TaskCompletedEvent) error {
+//		...
+//	})
+//	rc, err := receiver.New(secret, r.Handle, nil)
+package router
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/onfleet/gonfleet"
+)
+
+const defaultDedupeWindow = 10 * time.Minute
+
+// ErrClosed is returned by Handle once the Router is closed.
+var ErrClosed = errors.New("webhook router closed")
+
+// Event is a webhook delivery decoded for its trigger.
+type Event struct {
+	onfleet.WebhookEventMeta
+	// Value is the typed event e.g. onfleet.TaskCompletedEvent.
+	Value any
+	// Payload is the delivery as received.
+	Payload onfleet.WebhookPayload
+}
+
+// Key identifies the event for de-duplication. Onfleet payloads carry no
+// event id so the trigger, task, worker and time are combined.
+func (e Event) Key() string {
+	var workerId string
+	if e.WorkerId != nil {
+		workerId = *e.WorkerId
+	}
+	return fmt.Sprintf("%d/%s/%s/%d", e.TriggerId, e.TaskId, workerId, e.Time)
+}
+
+// Handler handles an Event.
+type Handler func(ctx context.Context, event Event) error
+
+// Middleware wraps a Handler, e.g. to log or recover.
+type Middleware func(next Handler) Handler
+
+// Params overrides Router defaults.
+type Params struct {
+	// Workers, if positive, handles events asynchronously on this many
+	// goroutines. Handle then returns once the event is queued, so handler
+	// errors go to OnError instead of failing the delivery.
+	// 0 handles events synchronously within Handle.
+	Workers int
+	// QueueSize bounds the events waiting for a worker. Handle blocks while
+	// the queue is full. Defaults to Workers.
+	QueueSize int
+	// DedupeWindow is how long a handled event is remembered so that
+	// redeliveries are skipped. Defaults to 10 minutes, negative disables.
+	DedupeWindow time.Duration
+	// OnError, if set, is called with every error returned by an
+	// asynchronous handler.
+	OnError func(event Event, err error)
+}
+
+// Router dispatches events to the handlers registered for their trigger.
+// Register handlers and middleware before handling the first event.
+type Router struct {
+	handlers       map[onfleet.WebhookTrigger][]Handler
+	defaultHandler Handler
+	middleware     []Middleware
+	dedupeWindow   time.Duration
+	onError        func(event Event, err error)
+	now            func() time.Time
+
+	mu     sync.Mutex
+	seen   map[string]time.Time
+	closed bool
+
+	queue   chan queuedEvent
+	sending sync.WaitGroup
+	wg      sync.WaitGroup
+}
+
+type queuedEvent struct {
+	ctx   context.Context
+	event Event
+}
+
+// New creates a Router. params may be nil.
+func New(params *Params) *Router {
+	r := &Router{
+		handlers:     map[onfleet.WebhookTrigger][]Handler{},
+		dedupeWindow: defaultDedupeWindow,
+		now:          time.Now,
+		seen:         map[string]time.Time{},
+	}
+	if params == nil {
+		return r
+	}
+	if params.DedupeWindow != 0 {
+		r.dedupeWindow = params.DedupeWindow
+	}
+	r.onError = params.OnError
+	if params.Workers > 0 {
+		queueSize := params.QueueSize
+		if queueSize <= 0 {
+			queueSize = params.Workers
+		}
+		r.queue = make(chan queuedEvent, queueSize)
+		for i := 0; i < params.Workers; i++ {
+			r.wg.Add(1)
+			go r.work()
+		}
+	}
+	return r
+}
+
+// Use appends middleware, the first added being the outermost.
+func (r *Router) Use(middleware ...Middleware) {
+	r.middleware = append(r.middleware, middleware...)
+}
+
+// On registers h for trigger. Handlers of the same trigger run in
+// registration order until one fails.
+func (r *Router) On(trigger onfleet.WebhookTrigger, h Handler) {
+	r.handlers[trigger] = append(r.handlers[trigger], h)
+}
+
+// Default registers h for triggers without a handler.
+// Events without a handler are otherwise acknowledged and dropped.
+func (r *Router) Default(h Handler) {
+	r.defaultHandler = h
+}
+
+// Handle decodes payload and dispatches it. Its signature matches
+// receiver.HandlerFunc.
+func (r *Router) Handle(ctx context.Context, payload onfleet.WebhookPayload) error {
+	value, err := payload.Event()
+	if err != nil {
+		return err
+	}
+	event := Event{
+		WebhookEventMeta: payload.WebhookEventMeta,
+		Value:            value,
+		Payload:          payload,
+	}
+	key := event.Key()
+
+	r.mu.Lock()
+	if r.closed {
+		r.mu.Unlock()
+		return ErrClosed
+	}
+	if !r.reserve(key) {
+		r.mu.Unlock()
+		return nil
+	}
+	if r.queue != nil {
+		r.sending.Add(1)
+	}
+	r.mu.Unlock()
+
+	if r.queue == nil {
+		return r.dispatch(ctx, event)
+	}
+	defer r.sending.Done()
+	// the delivery's context ends with the http response, give asynchronous
+	// handlers their own
+	select {
+	case r.queue <- queuedEvent{ctx: context.Background(), event: event}:
+		return nil
+	case <-ctx.Done():
+		r.release(key)
+		return ctx.Err()
+	}
+}
+
+// Close stops accepting events and waits for queued events to be handled.
+func (r *Router) Close() {
+	r.mu.Lock()
+	if r.closed {
+		r.mu.Unlock()
+		return
+	}
+	r.closed = true
+	r.mu.Unlock()
+	if r.queue != nil {
+		r.sending.Wait()
+		close(r.queue)
+		r.wg.Wait()
+	}
+}
+
+func (r *Router) work() {
+	defer r.wg.Done()
+	for q := range r.queue {
+		if err := r.dispatch(q.ctx, q.event); err != nil && r.onError != nil {
+			r.onError(q.event, err)
+		}
+	}
+}
+
+func (r *Router) dispatch(ctx context.Context, event Event) error {
+	h := r.route
+	for i := len(r.middleware) - 1; i >= 0; i-- {
+		h = r.middleware[i](h)
+	}
+	err := h(ctx, event)
+	if err != nil {
+		// let the redelivery through
+		r.release(event.Key())
+	}
+	return err
+}
+
+func (r *Router) route(ctx context.Context, event Event) error {
+	handlers := r.handlers[event.TriggerId]
+	if len(handlers) == 0 && r.defaultHandler != nil {
+		handlers = []Handler{r.defaultHandler}
+	}
+	for _, h := range handlers {
+		if err := h(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reserve marks key as seen, reporting false if it already was.
+// r.mu must be held.
+func (r *Router) reserve(key string) bool {
+	if r.dedupeWindow < 0 {
+		return true
+	}
+	now := r.now()
+	if expires, ok := r.seen[key]; ok && now.Before(expires) {
+		return false
+	}
+	for k, expires := range r.seen {
+		if !now.Before(expires) {
+			delete(r.seen, k)
+		}
+	}
+	r.seen[key] = now.Add(r.dedupeWindow)
+	return true
+}
+
+func (r *Router) release(key string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.seen, key)
+}
+
+// Recover turns a handler panic into an error.
+func Recover() Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, event Event) (err error) {
+			defer func() {
+				if p := recover(); p != nil {
+					err = fmt.Errorf("webhook handler panic: %v\n%s", p, debug.Stack())
+				}
+			}()
+			return next(ctx, event)
+		}
+	}
+}
+
+// Logging logs every handled event with its duration and error.
+// A nil logger logs to log.Default().
+func Logging(logger *log.Logger) Middleware {
+	if logger == nil {
+		logger = log.Default()
+	}
+	return func(next Handler) Handler {
+		return func(ctx context.Context, event Event) error {
+			start := time.Now()
+			err := next(ctx, event)
+			if err != nil {
+				logger.Printf("webhook %s task=%s time=%d failed after %s: %v", event.TriggerId, event.TaskId, event.Time, time.Since(start), err)
+			} else {
+				logger.Printf("webhook %s task=%s time=%d handled in %s", event.TriggerId, event.TaskId, event.Time, time.Since(start))
+			}
+			return err
+		}
+	}
+}
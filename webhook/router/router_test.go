@@ -0,0 +1,206 @@
+package router
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/onfleet/gonfleet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func payload(t *testing.T, body string) onfleet.WebhookPayload {
+	t.Helper()
+	var p onfleet.WebhookPayload
+	require.NoError(t, json.Unmarshal([]byte(body), &p))
+	return p
+}
+
+const taskCompleted = `{"taskId": "task_1", "time": 1700000000000, "triggerId": 3, "triggerName": "taskCompleted", "data": {"task": {"id": "task_1"}}}`
+
+func TestRouter_Dispatch(t *testing.T) {
+	r := New(nil)
+	var completed []string
+	r.OnTaskCompleted(func(ctx context.Context, event onfleet.TaskCompletedEvent) error {
+		completed = append(completed, event.Data.Task.ID)
+		return nil
+	})
+	var duty int
+	r.OnWorkerDuty(func(ctx context.Context, event onfleet.WorkerDutyEvent) error {
+		duty++
+		return nil
+	})
+
+	require.NoError(t, r.Handle(context.Background(), payload(t, taskCompleted)))
+	assert.Equal(t, []string{"task_1"}, completed)
+	assert.Equal(t, 0, duty)
+
+	// no handler and no default: acknowledged
+	assert.NoError(t, r.Handle(context.Background(), payload(t, `{"time": 1, "triggerId": 0, "data": {"task": {"id": "task_2"}}}`)))
+}
+
+func TestRouter_Default(t *testing.T) {
+	r := New(nil)
+	var got []onfleet.WebhookTrigger
+	r.Default(func(ctx context.Context, event Event) error {
+		got = append(got, event.TriggerId)
+		return nil
+	})
+	r.OnTaskCompleted(func(ctx context.Context, event onfleet.TaskCompletedEvent) error {
+		return nil
+	})
+
+	require.NoError(t, r.Handle(context.Background(), payload(t, taskCompleted)))
+	require.NoError(t, r.Handle(context.Background(), payload(t, `{"time": 1, "triggerId": 18}`)))
+	assert.Equal(t, []onfleet.WebhookTrigger{onfleet.WebhookTriggerAutoDispatchJobCompleted}, got)
+}
+
+func TestRouter_Dedupe(t *testing.T) {
+	r := New(nil)
+	now := time.Unix(1700000000, 0)
+	r.now = func() time.Time { return now }
+	var calls int
+	fail := true
+	r.OnTaskCompleted(func(ctx context.Context, event onfleet.TaskCompletedEvent) error {
+		calls++
+		if fail {
+			return errors.New("boom")
+		}
+		return nil
+	})
+
+	// a failed event is not remembered so its redelivery is handled
+	assert.Error(t, r.Handle(context.Background(), payload(t, taskCompleted)))
+	fail = false
+	assert.NoError(t, r.Handle(context.Background(), payload(t, taskCompleted)))
+	assert.NoError(t, r.Handle(context.Background(), payload(t, taskCompleted)))
+	assert.Equal(t, 2, calls)
+
+	now = now.Add(defaultDedupeWindow)
+	assert.NoError(t, r.Handle(context.Background(), payload(t, taskCompleted)))
+	assert.Equal(t, 3, calls)
+}
+
+func TestRouter_DedupeDisabled(t *testing.T) {
+	r := New(&Params{DedupeWindow: -1})
+	var calls int
+	r.OnTaskCompleted(func(ctx context.Context, event onfleet.TaskCompletedEvent) error {
+		calls++
+		return nil
+	})
+	r.Handle(context.Background(), payload(t, taskCompleted))
+	r.Handle(context.Background(), payload(t, taskCompleted))
+	assert.Equal(t, 2, calls)
+}
+
+func TestRouter_Middleware(t *testing.T) {
+	r := New(nil)
+	var order []string
+	mark := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(ctx context.Context, event Event) error {
+				order = append(order, name)
+				return next(ctx, event)
+			}
+		}
+	}
+	r.Use(mark("first"), mark("second"))
+	r.OnTaskCompleted(func(ctx context.Context, event onfleet.TaskCompletedEvent) error {
+		order = append(order, "handler")
+		return nil
+	})
+
+	require.NoError(t, r.Handle(context.Background(), payload(t, taskCompleted)))
+	assert.Equal(t, []string{"first", "second", "handler"}, order)
+}
+
+func TestRecover(t *testing.T) {
+	r := New(nil)
+	r.Use(Recover())
+	r.OnTaskCompleted(func(ctx context.Context, event onfleet.TaskCompletedEvent) error {
+		panic("oops")
+	})
+	err := r.Handle(context.Background(), payload(t, taskCompleted))
+	assert.ErrorContains(t, err, "webhook handler panic: oops")
+}
+
+func TestLogging(t *testing.T) {
+	var buf bytes.Buffer
+	r := New(nil)
+	r.Use(Logging(log.New(&buf, "", 0)))
+	r.OnTaskCompleted(func(ctx context.Context, event onfleet.TaskCompletedEvent) error {
+		return nil
+	})
+	require.NoError(t, r.Handle(context.Background(), payload(t, taskCompleted)))
+	assert.True(t, strings.HasPrefix(buf.String(), "webhook taskCompleted task=task_1"), buf.String())
+}
+
+func TestLogging_NilLogger(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	r := New(nil)
+	r.Use(Logging(nil))
+	r.OnTaskCompleted(func(ctx context.Context, event onfleet.TaskCompletedEvent) error {
+		return nil
+	})
+	require.NoError(t, r.Handle(context.Background(), payload(t, taskCompleted)))
+	assert.Contains(t, buf.String(), "webhook taskCompleted task=task_1")
+}
+
+func TestRouter_Async(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		errs   []error
+		active int32
+		peak   int32
+	)
+	r := New(&Params{
+		Workers:   2,
+		QueueSize: 8,
+		OnError: func(event Event, err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			errs = append(errs, err)
+		},
+	})
+	var handled int32
+	r.OnTaskStarted(func(ctx context.Context, event onfleet.TaskStartedEvent) error {
+		n := atomic.AddInt32(&active, 1)
+		defer atomic.AddInt32(&active, -1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		atomic.AddInt32(&handled, 1)
+		if event.Time == 3 {
+			return errors.New("boom")
+		}
+		return nil
+	})
+
+	for i := 1; i <= 6; i++ {
+		body := `{"time": ` + string(rune('0'+i)) + `, "triggerId": 0, "data": {"task": {"id": "t"}}}`
+		require.NoError(t, r.Handle(context.Background(), payload(t, body)))
+	}
+	r.Close()
+
+	assert.Equal(t, int32(6), handled)
+	assert.LessOrEqual(t, peak, int32(2))
+	require.Len(t, errs, 1)
+	assert.EqualError(t, errs[0], "boom")
+	assert.ErrorIs(t, r.Handle(context.Background(), payload(t, taskCompleted)), ErrClosed)
+}
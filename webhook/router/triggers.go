@@ -0,0 +1,147 @@
+package router
+
+import (
+	"context"
+
+	"github.com/onfleet/gonfleet"
+)
+
+// OnTaskStarted registers fn for the taskStarted trigger.
+func (r *Router) OnTaskStarted(fn func(ctx context.Context, event onfleet.TaskStartedEvent) error) {
+	r.On(onfleet.WebhookTriggerTaskStarted, func(ctx context.Context, event Event) error {
+		return fn(ctx, event.Value.(onfleet.TaskStartedEvent))
+	})
+}
+
+// OnTaskEta registers fn for the taskEta trigger.
+func (r *Router) OnTaskEta(fn func(ctx context.Context, event onfleet.TaskEtaEvent) error) {
+	r.On(onfleet.WebhookTriggerTaskEta, func(ctx context.Context, event Event) error {
+		return fn(ctx, event.Value.(onfleet.TaskEtaEvent))
+	})
+}
+
+// OnTaskArrival registers fn for the taskArrival trigger.
+func (r *Router) OnTaskArrival(fn func(ctx context.Context, event onfleet.TaskArrivalEvent) error) {
+	r.On(onfleet.WebhookTriggerTaskArrival, func(ctx context.Context, event Event) error {
+		return fn(ctx, event.Value.(onfleet.TaskArrivalEvent))
+	})
+}
+
+// OnTaskCompleted registers fn for the taskCompleted trigger.
+func (r *Router) OnTaskCompleted(fn func(ctx context.Context, event onfleet.TaskCompletedEvent) error) {
+	r.On(onfleet.WebhookTriggerTaskCompleted, func(ctx context.Context, event Event) error {
+		return fn(ctx, event.Value.(onfleet.TaskCompletedEvent))
+	})
+}
+
+// OnTaskFailed registers fn for the taskFailed trigger.
+func (r *Router) OnTaskFailed(fn func(ctx context.Context, event onfleet.TaskFailedEvent) error) {
+	r.On(onfleet.WebhookTriggerTaskFailed, func(ctx context.Context, event Event) error {
+		return fn(ctx, event.Value.(onfleet.TaskFailedEvent))
+	})
+}
+
+// OnWorkerDuty registers fn for the workerDuty trigger.
+func (r *Router) OnWorkerDuty(fn func(ctx context.Context, event onfleet.WorkerDutyEvent) error) {
+	r.On(onfleet.WebhookTriggerWorkerDuty, func(ctx context.Context, event Event) error {
+		return fn(ctx, event.Value.(onfleet.WorkerDutyEvent))
+	})
+}
+
+// OnTaskCreated registers fn for the taskCreated trigger.
+func (r *Router) OnTaskCreated(fn func(ctx context.Context, event onfleet.TaskCreatedEvent) error) {
+	r.On(onfleet.WebhookTriggerTaskCreated, func(ctx context.Context, event Event) error {
+		return fn(ctx, event.Value.(onfleet.TaskCreatedEvent))
+	})
+}
+
+// OnTaskUpdated registers fn for the taskUpdated trigger.
+func (r *Router) OnTaskUpdated(fn func(ctx context.Context, event onfleet.TaskUpdatedEvent) error) {
+	r.On(onfleet.WebhookTriggerTaskUpdated, func(ctx context.Context, event Event) error {
+		return fn(ctx, event.Value.(onfleet.TaskUpdatedEvent))
+	})
+}
+
+// OnTaskDeleted registers fn for the taskDeleted trigger.
+func (r *Router) OnTaskDeleted(fn func(ctx context.Context, event onfleet.TaskDeletedEvent) error) {
+	r.On(onfleet.WebhookTriggerTaskDeleted, func(ctx context.Context, event Event) error {
+		return fn(ctx, event.Value.(onfleet.TaskDeletedEvent))
+	})
+}
+
+// OnTaskAssigned registers fn for the taskAssigned trigger.
+func (r *Router) OnTaskAssigned(fn func(ctx context.Context, event onfleet.TaskAssignedEvent) error) {
+	r.On(onfleet.WebhookTriggerTaskAssigned, func(ctx context.Context, event Event) error {
+		return fn(ctx, event.Value.(onfleet.TaskAssignedEvent))
+	})
+}
+
+// OnTaskUnassigned registers fn for the taskUnassigned trigger.
+func (r *Router) OnTaskUnassigned(fn func(ctx context.Context, event onfleet.TaskUnassignedEvent) error) {
+	r.On(onfleet.WebhookTriggerTaskUnassigned, func(ctx context.Context, event Event) error {
+		return fn(ctx, event.Value.(onfleet.TaskUnassignedEvent))
+	})
+}
+
+// OnTaskDelayed registers fn for the taskDelayed trigger.
+func (r *Router) OnTaskDelayed(fn func(ctx context.Context, event onfleet.TaskDelayedEvent) error) {
+	r.On(onfleet.WebhookTriggerTaskDelayed, func(ctx context.Context, event Event) error {
+		return fn(ctx, event.Value.(onfleet.TaskDelayedEvent))
+	})
+}
+
+// OnTaskCloned registers fn for the taskCloned trigger.
+func (r *Router) OnTaskCloned(fn func(ctx context.Context, event onfleet.TaskClonedEvent) error) {
+	r.On(onfleet.WebhookTriggerTaskCloned, func(ctx context.Context, event Event) error {
+		return fn(ctx, event.Value.(onfleet.TaskClonedEvent))
+	})
+}
+
+// OnSmsRecipientResponseMissed registers fn for the smsRecipientResponseMissed trigger.
+func (r *Router) OnSmsRecipientResponseMissed(fn func(ctx context.Context, event onfleet.SmsRecipientResponseMissedEvent) error) {
+	r.On(onfleet.WebhookTriggerSmsRecipientResponseMissed, func(ctx context.Context, event Event) error {
+		return fn(ctx, event.Value.(onfleet.SmsRecipientResponseMissedEvent))
+	})
+}
+
+// OnWorkerCreated registers fn for the workerCreated trigger.
+func (r *Router) OnWorkerCreated(fn func(ctx context.Context, event onfleet.WorkerCreatedEvent) error) {
+	r.On(onfleet.WebhookTriggerWorkerCreated, func(ctx context.Context, event Event) error {
+		return fn(ctx, event.Value.(onfleet.WorkerCreatedEvent))
+	})
+}
+
+// OnWorkerDeleted registers fn for the workerDeleted trigger.
+func (r *Router) OnWorkerDeleted(fn func(ctx context.Context, event onfleet.WorkerDeletedEvent) error) {
+	r.On(onfleet.WebhookTriggerWorkerDeleted, func(ctx context.Context, event Event) error {
+		return fn(ctx, event.Value.(onfleet.WorkerDeletedEvent))
+	})
+}
+
+// OnSmsRecipientOptOut registers fn for the SMSRecipientOptOut trigger.
+func (r *Router) OnSmsRecipientOptOut(fn func(ctx context.Context, event onfleet.SmsRecipientOptOutEvent) error) {
+	r.On(onfleet.WebhookTriggerSmsRecipientOptOut, func(ctx context.Context, event Event) error {
+		return fn(ctx, event.Value.(onfleet.SmsRecipientOptOutEvent))
+	})
+}
+
+// OnAutoDispatchJobCompleted registers fn for the autoDispatchJobCompleted trigger.
+func (r *Router) OnAutoDispatchJobCompleted(fn func(ctx context.Context, event onfleet.AutoDispatchJobCompletedEvent) error) {
+	r.On(onfleet.WebhookTriggerAutoDispatchJobCompleted, func(ctx context.Context, event Event) error {
+		return fn(ctx, event.Value.(onfleet.AutoDispatchJobCompletedEvent))
+	})
+}
+
+// OnTaskBatchCreateJobCompleted registers fn for the taskBatchCreateJobCompleted trigger.
+func (r *Router) OnTaskBatchCreateJobCompleted(fn func(ctx context.Context, event onfleet.TaskBatchCreateJobCompletedEvent) error) {
+	r.On(onfleet.WebhookTriggerTaskBatchCreateJobCompleted, func(ctx context.Context, event Event) error {
+		return fn(ctx, event.Value.(onfleet.TaskBatchCreateJobCompletedEvent))
+	})
+}
+
+// OnRouteOptimizationJobCompleted registers fn for the routeOptimizationJobCompleted trigger.
+func (r *Router) OnRouteOptimizationJobCompleted(fn func(ctx context.Context, event onfleet.RouteOptimizationJobCompletedEvent) error) {
+	r.On(onfleet.WebhookTriggerRouteOptimizationJobCompleted, func(ctx context.Context, event Event) error {
+		return fn(ctx, event.Value.(onfleet.RouteOptimizationJobCompletedEvent))
+	})
+}
@@ -0,0 +1,264 @@
+package onfleet
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// WebhookTrigger identifies the event a webhook fires on.
+// Reference https://docs.onfleet.com/reference/webhooks#webhook-triggers
+type WebhookTrigger int
+
+const (
+	WebhookTriggerTaskStarted                   WebhookTrigger = 0
+	WebhookTriggerTaskEta                       WebhookTrigger = 1
+	WebhookTriggerTaskArrival                   WebhookTrigger = 2
+	WebhookTriggerTaskCompleted                 WebhookTrigger = 3
+	WebhookTriggerTaskFailed                    WebhookTrigger = 4
+	WebhookTriggerWorkerDuty                    WebhookTrigger = 5
+	WebhookTriggerTaskCreated                   WebhookTrigger = 6
+	WebhookTriggerTaskUpdated                   WebhookTrigger = 7
+	WebhookTriggerTaskDeleted                   WebhookTrigger = 8
+	WebhookTriggerTaskAssigned                  WebhookTrigger = 9
+	WebhookTriggerTaskUnassigned                WebhookTrigger = 10
+	WebhookTriggerTaskDelayed                   WebhookTrigger = 12
+	WebhookTriggerTaskCloned                    WebhookTrigger = 13
+	WebhookTriggerSmsRecipientResponseMissed    WebhookTrigger = 14
+	WebhookTriggerWorkerCreated                 WebhookTrigger = 15
+	WebhookTriggerWorkerDeleted                 WebhookTrigger = 16
+	WebhookTriggerSmsRecipientOptOut            WebhookTrigger = 17
+	WebhookTriggerAutoDispatchJobCompleted      WebhookTrigger = 18
+	WebhookTriggerTaskBatchCreateJobCompleted   WebhookTrigger = 19
+	WebhookTriggerRouteOptimizationJobCompleted WebhookTrigger = 20
+)
+
+var webhookTriggerNames = map[WebhookTrigger]string{
+	WebhookTriggerTaskStarted:                   "taskStarted",
+	WebhookTriggerTaskEta:                       "taskEta",
+	WebhookTriggerTaskArrival:                   "taskArrival",
+	WebhookTriggerTaskCompleted:                 "taskCompleted",
+	WebhookTriggerTaskFailed:                    "taskFailed",
+	WebhookTriggerWorkerDuty:                    "workerDuty",
+	WebhookTriggerTaskCreated:                   "taskCreated",
+	WebhookTriggerTaskUpdated:                   "taskUpdated",
+	WebhookTriggerTaskDeleted:                   "taskDeleted",
+	WebhookTriggerTaskAssigned:                  "taskAssigned",
+	WebhookTriggerTaskUnassigned:                "taskUnassigned",
+	WebhookTriggerTaskDelayed:                   "taskDelayed",
+	WebhookTriggerTaskCloned:                    "taskCloned",
+	WebhookTriggerSmsRecipientResponseMissed:    "smsRecipientResponseMissed",
+	WebhookTriggerWorkerCreated:                 "workerCreated",
+	WebhookTriggerWorkerDeleted:                 "workerDeleted",
+	WebhookTriggerSmsRecipientOptOut:            "SMSRecipientOptOut",
+	WebhookTriggerAutoDispatchJobCompleted:      "autoDispatchJobCompleted",
+	WebhookTriggerTaskBatchCreateJobCompleted:   "taskBatchCreateJobCompleted",
+	WebhookTriggerRouteOptimizationJobCompleted: "routeOptimizationJobCompleted",
+}
+
+// WebhookTriggers lists every known trigger.
+func WebhookTriggers() []WebhookTrigger {
+	return []WebhookTrigger{
+		WebhookTriggerTaskStarted,
+		WebhookTriggerTaskEta,
+		WebhookTriggerTaskArrival,
+		WebhookTriggerTaskCompleted,
+		WebhookTriggerTaskFailed,
+		WebhookTriggerWorkerDuty,
+		WebhookTriggerTaskCreated,
+		WebhookTriggerTaskUpdated,
+		WebhookTriggerTaskDeleted,
+		WebhookTriggerTaskAssigned,
+		WebhookTriggerTaskUnassigned,
+		WebhookTriggerTaskDelayed,
+		WebhookTriggerTaskCloned,
+		WebhookTriggerSmsRecipientResponseMissed,
+		WebhookTriggerWorkerCreated,
+		WebhookTriggerWorkerDeleted,
+		WebhookTriggerSmsRecipientOptOut,
+		WebhookTriggerAutoDispatchJobCompleted,
+		WebhookTriggerTaskBatchCreateJobCompleted,
+		WebhookTriggerRouteOptimizationJobCompleted,
+	}
+}
+
+// String returns the trigger name used by Onfleet, e.g. "taskCompleted".
+func (t WebhookTrigger) String() string {
+	if name, ok := webhookTriggerNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("WebhookTrigger(%d)", int(t))
+}
+
+// ParseWebhookTrigger returns the trigger named name, e.g. "taskCompleted".
+func ParseWebhookTrigger(name string) (WebhookTrigger, error) {
+	for t, n := range webhookTriggerNames {
+		if n == name {
+			return t, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown webhook trigger: %s", name)
+}
+
+// TaskEvent is the payload of task triggers.
+type TaskEvent struct {
+	WebhookEventMeta
+	Data TaskEventData `json:"data"`
+}
+
+type TaskEventData struct {
+	Task Task `json:"task"`
+	// Worker is present when a worker is involved, e.g. taskStarted.
+	Worker *Worker `json:"worker,omitempty"`
+}
+
+// WorkerEvent is the payload of worker triggers.
+type WorkerEvent struct {
+	WebhookEventMeta
+	Data WorkerEventData `json:"data"`
+}
+
+type WorkerEventData struct {
+	Worker Worker `json:"worker"`
+}
+
+// RawEvent is the payload of triggers whose data is not modelled.
+type RawEvent struct {
+	WebhookEventMeta
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+// TaskStartedEvent is the payload of the taskStarted trigger.
+type TaskStartedEvent TaskEvent
+
+// TaskEtaEvent is the payload of the taskEta trigger.
+type TaskEtaEvent TaskEvent
+
+// TaskArrivalEvent is the payload of the taskArrival trigger.
+type TaskArrivalEvent TaskEvent
+
+// TaskCompletedEvent is the payload of the taskCompleted trigger.
+type TaskCompletedEvent TaskEvent
+
+// TaskFailedEvent is the payload of the taskFailed trigger.
+type TaskFailedEvent TaskEvent
+
+// WorkerDutyEvent is the payload of the workerDuty trigger.
+type WorkerDutyEvent WorkerEvent
+
+// TaskCreatedEvent is the payload of the taskCreated trigger.
+type TaskCreatedEvent TaskEvent
+
+// TaskUpdatedEvent is the payload of the taskUpdated trigger.
+type TaskUpdatedEvent TaskEvent
+
+// TaskDeletedEvent is the payload of the taskDeleted trigger.
+type TaskDeletedEvent TaskEvent
+
+// TaskAssignedEvent is the payload of the taskAssigned trigger.
+type TaskAssignedEvent TaskEvent
+
+// TaskUnassignedEvent is the payload of the taskUnassigned trigger.
+type TaskUnassignedEvent TaskEvent
+
+// TaskDelayedEvent is the payload of the taskDelayed trigger.
+type TaskDelayedEvent TaskEvent
+
+// TaskClonedEvent is the payload of the taskCloned trigger.
+type TaskClonedEvent TaskEvent
+
+// SmsRecipientResponseMissedEvent is the payload of the smsRecipientResponseMissed trigger.
+type SmsRecipientResponseMissedEvent RawEvent
+
+// WorkerCreatedEvent is the payload of the workerCreated trigger.
+type WorkerCreatedEvent WorkerEvent
+
+// WorkerDeletedEvent is the payload of the workerDeleted trigger.
+type WorkerDeletedEvent WorkerEvent
+
+// SmsRecipientOptOutEvent is the payload of the SMSRecipientOptOut trigger.
+type SmsRecipientOptOutEvent RawEvent
+
+// AutoDispatchJobCompletedEvent is the payload of the autoDispatchJobCompleted trigger.
+type AutoDispatchJobCompletedEvent RawEvent
+
+// TaskBatchCreateJobCompletedEvent is the payload of the taskBatchCreateJobCompleted trigger.
+type TaskBatchCreateJobCompletedEvent RawEvent
+
+// RouteOptimizationJobCompletedEvent is the payload of the routeOptimizationJobCompleted trigger.
+type RouteOptimizationJobCompletedEvent RawEvent
+
+// Event decodes the payload into the typed event of its trigger, e.g.
+// TaskCompletedEvent for WebhookTriggerTaskCompleted.
+// Unknown triggers decode to RawEvent.
+func (p WebhookPayload) Event() (any, error) {
+	switch p.TriggerId {
+	case WebhookTriggerTaskStarted, WebhookTriggerTaskEta, WebhookTriggerTaskArrival, WebhookTriggerTaskCompleted, WebhookTriggerTaskFailed, WebhookTriggerTaskCreated, WebhookTriggerTaskUpdated, WebhookTriggerTaskDeleted, WebhookTriggerTaskAssigned, WebhookTriggerTaskUnassigned, WebhookTriggerTaskDelayed, WebhookTriggerTaskCloned:
+		event := TaskEvent{WebhookEventMeta: p.WebhookEventMeta}
+		if err := p.decodeData(&event.Data); err != nil {
+			return nil, err
+		}
+		switch p.TriggerId {
+		case WebhookTriggerTaskStarted:
+			return TaskStartedEvent(event), nil
+		case WebhookTriggerTaskEta:
+			return TaskEtaEvent(event), nil
+		case WebhookTriggerTaskArrival:
+			return TaskArrivalEvent(event), nil
+		case WebhookTriggerTaskCompleted:
+			return TaskCompletedEvent(event), nil
+		case WebhookTriggerTaskFailed:
+			return TaskFailedEvent(event), nil
+		case WebhookTriggerTaskCreated:
+			return TaskCreatedEvent(event), nil
+		case WebhookTriggerTaskUpdated:
+			return TaskUpdatedEvent(event), nil
+		case WebhookTriggerTaskDeleted:
+			return TaskDeletedEvent(event), nil
+		case WebhookTriggerTaskAssigned:
+			return TaskAssignedEvent(event), nil
+		case WebhookTriggerTaskUnassigned:
+			return TaskUnassignedEvent(event), nil
+		case WebhookTriggerTaskDelayed:
+			return TaskDelayedEvent(event), nil
+		case WebhookTriggerTaskCloned:
+			return TaskClonedEvent(event), nil
+		}
+	case WebhookTriggerWorkerDuty, WebhookTriggerWorkerCreated, WebhookTriggerWorkerDeleted:
+		event := WorkerEvent{WebhookEventMeta: p.WebhookEventMeta}
+		if err := p.decodeData(&event.Data); err != nil {
+			return nil, err
+		}
+		switch p.TriggerId {
+		case WebhookTriggerWorkerDuty:
+			return WorkerDutyEvent(event), nil
+		case WebhookTriggerWorkerCreated:
+			return WorkerCreatedEvent(event), nil
+		case WebhookTriggerWorkerDeleted:
+			return WorkerDeletedEvent(event), nil
+		}
+	}
+	event := RawEvent{WebhookEventMeta: p.WebhookEventMeta, Data: p.Data}
+	switch p.TriggerId {
+	case WebhookTriggerSmsRecipientResponseMissed:
+		return SmsRecipientResponseMissedEvent(event), nil
+	case WebhookTriggerSmsRecipientOptOut:
+		return SmsRecipientOptOutEvent(event), nil
+	case WebhookTriggerAutoDispatchJobCompleted:
+		return AutoDispatchJobCompletedEvent(event), nil
+	case WebhookTriggerTaskBatchCreateJobCompleted:
+		return TaskBatchCreateJobCompletedEvent(event), nil
+	case WebhookTriggerRouteOptimizationJobCompleted:
+		return RouteOptimizationJobCompletedEvent(event), nil
+	}
+	return event, nil
+}
+
+func (p WebhookPayload) decodeData(v any) error {
+	if len(p.Data) == 0 || string(p.Data) == "null" {
+		return nil
+	}
+	if err := json.Unmarshal(p.Data, v); err != nil {
+		return fmt.Errorf("decoding %s webhook data: %w", p.TriggerId, err)
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package onfleet
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookTrigger_String(t *testing.T) {
+	assert.Equal(t, "taskStarted", WebhookTriggerTaskStarted.String())
+	assert.Equal(t, "taskCompleted", WebhookTriggerTaskCompleted.String())
+	assert.Equal(t, "SMSRecipientOptOut", WebhookTriggerSmsRecipientOptOut.String())
+	assert.Equal(t, "WebhookTrigger(11)", WebhookTrigger(11).String())
+}
+
+func TestParseWebhookTrigger(t *testing.T) {
+	for _, trigger := range WebhookTriggers() {
+		parsed, err := ParseWebhookTrigger(trigger.String())
+		require.NoError(t, err)
+		assert.Equal(t, trigger, parsed)
+	}
+	_, err := ParseWebhookTrigger("taskExploded")
+	assert.Error(t, err)
+}
+
+func decodePayload(t *testing.T, body string) WebhookPayload {
+	t.Helper()
+	var payload WebhookPayload
+	require.NoError(t, json.Unmarshal([]byte(body), &payload))
+	return payload
+}
+
+func TestWebhookPayload_Event_Task(t *testing.T) {
+	payload := decodePayload(t, `{
+		"taskId": "task_1",
+		"time": 1700000000000,
+		"triggerId": 3,
+		"triggerName": "taskCompleted",
+		"workerId": "worker_1",
+		"adminId": null,
+		"actionContext": {"id": "worker_1", "type": "WORKER"},
+		"data": {
+			"task": {"id": "task_1", "shortId": "abc", "state": 3},
+			"worker": {"id": "worker_1", "name": "Jane"}
+		}
+	}`)
+
+	event, err := payload.Event()
+	require.NoError(t, err)
+	completed, ok := event.(TaskCompletedEvent)
+	require.True(t, ok, "got %T", event)
+	assert.Equal(t, WebhookTriggerTaskCompleted, completed.TriggerId)
+	assert.Equal(t, "task_1", completed.TaskId)
+	assert.Equal(t, "WORKER", completed.ActionContext.Type)
+	assert.Equal(t, "task_1", completed.Data.Task.ID)
+	assert.Equal(t, "abc", completed.Data.Task.ShortId)
+	require.NotNil(t, completed.Data.Worker)
+	assert.Equal(t, "Jane", completed.Data.Worker.Name)
+}
+
+func TestWebhookPayload_Event_Worker(t *testing.T) {
+	payload := decodePayload(t, `{
+		"time": 1700000000000,
+		"triggerId": 5,
+		"triggerName": "workerDuty",
+		"workerId": "worker_1",
+		"data": {"worker": {"id": "worker_1", "onDuty": true}}
+	}`)
+
+	event, err := payload.Event()
+	require.NoError(t, err)
+	duty, ok := event.(WorkerDutyEvent)
+	require.True(t, ok, "got %T", event)
+	assert.Equal(t, "worker_1", duty.Data.Worker.ID)
+	assert.True(t, duty.Data.Worker.OnDuty)
+}
+
+func TestWebhookPayload_Event_Raw(t *testing.T) {
+	payload := decodePayload(t, `{"time": 1, "triggerId": 18, "data": {"dispatchId": "d_1"}}`)
+	event, err := payload.Event()
+	require.NoError(t, err)
+	job, ok := event.(AutoDispatchJobCompletedEvent)
+	require.True(t, ok, "got %T", event)
+	assert.JSONEq(t, `{"dispatchId": "d_1"}`, string(job.Data))
+
+	payload = decodePayload(t, `{"time": 1, "triggerId": 99}`)
+	event, err = payload.Event()
+	require.NoError(t, err)
+	assert.IsType(t, RawEvent{}, event)
+}
+
+func TestWebhookPayload_Event_InvalidData(t *testing.T) {
+	payload := decodePayload(t, `{"time": 1, "triggerId": 0, "data": {"task": "nope"}}`)
+	_, err := payload.Event()
+	assert.ErrorContains(t, err, "taskStarted")
+}
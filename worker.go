@@ -183,9 +183,11 @@ type WorkerVehicleParam struct {
 	Type         WorkerVehicleType `json:"type,omitempty"`
 }
 
+// WorkerUpdateParams only sends the fields set, nil and empty fields are
+// left unchanged.
 type WorkerUpdateParams struct {
 	Addresses   *WorkerAddressRoutingParam `json:"addresses,omitempty"`
-	Capacity    float64                    `json:"capacity,omitempty"`
+	Capacity    *float64                   `json:"capacity,omitempty"`
 	DisplayName string                     `json:"displayName,omitempty"`
 	Metadata    []Metadata                 `json:"metadata,omitempty"`
 	Name        string                     `json:"name,omitempty"`